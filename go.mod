@@ -1,3 +1,3 @@
 module github.com/luckyaibin/go-generic-list
 
-go 1.20
+go 1.23
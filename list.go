@@ -9,11 +9,26 @@
 //	for e := l.Front(); e != nil; e = e.Next() {
 //		// do something with e.Value
 //	}
+//
+// The iterators returned by methods of this package move past an element
+// before yielding its value, so the loop body may remove the element whose
+// value was just yielded. Removing or moving any other element of the list
+// during iteration is not supported.
 package list
 
 import (
+	"bytes"
+	"cmp"
+	"container/heap"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"iter"
+	"math/bits"
+	"math/rand"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // Element is an element of a linked list.
@@ -48,6 +63,78 @@ func (e *Element[T]) Prev() *Element[T] {
 	return nil
 }
 
+// List returns the list that element e belongs to, or nil if e is not an
+// element of any list.
+func (e *Element[T]) List() *List[T] {
+	return e.list
+}
+
+// Set sets the value of element e to v.
+func (e *Element[T]) Set(v T) {
+	e.Value = v
+}
+
+// MoveToFront moves element e to the front of its list.
+// If e is not an element of any list, it does nothing.
+func (e *Element[T]) MoveToFront() {
+	if e.list != nil {
+		e.list.MoveToFront(e)
+	}
+}
+
+// MoveToBack moves element e to the back of its list.
+// If e is not an element of any list, it does nothing.
+func (e *Element[T]) MoveToBack() {
+	if e.list != nil {
+		e.list.MoveToBack(e)
+	}
+}
+
+// MoveBefore moves element e to its new position before mark.
+// If e is not an element of any list, or mark is not an element of the
+// same list, it does nothing. The mark must not be nil.
+func (e *Element[T]) MoveBefore(mark *Element[T]) {
+	if e.list != nil {
+		e.list.MoveBefore(e, mark)
+	}
+}
+
+// MoveAfter moves element e to its new position after mark.
+// If e is not an element of any list, or mark is not an element of the
+// same list, it does nothing. The mark must not be nil.
+func (e *Element[T]) MoveAfter(mark *Element[T]) {
+	if e.list != nil {
+		e.list.MoveAfter(e, mark)
+	}
+}
+
+// Remove removes e from its list and returns the element value e.Value.
+// If e is not an element of any list, it only returns e.Value.
+func (e *Element[T]) Remove() T {
+	if e.list != nil {
+		return e.list.Remove(e)
+	}
+	return e.Value
+}
+
+// Take returns an iterator over the values of up to n elements, starting
+// at e and moving toward the back of its list. It stops early at the end
+// of the list, and yields nothing if e is not an element of any list.
+func (e *Element[T]) Take(n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if e.list == nil {
+			return
+		}
+		for p, k := e, n; p != nil && k > 0; k-- {
+			next := p.Next()
+			if !yield(p.Value) {
+				return
+			}
+			p = next
+		}
+	}
+}
+
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
 type List[T any] struct {
@@ -63,26 +150,49 @@ func (l *List[T]) Init() *List[T] {
 	return l
 }
 
+// Clear removes all elements from list l. Unlike Init, it detaches every
+// element from the list, so elements still referenced elsewhere do not
+// keep the rest of the old ring reachable.
+func (l *List[T]) Clear() {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		e.next = nil
+		e.prev = nil
+		e.list = nil
+		e = next
+	}
+	l.Init()
+}
+
 // New returns an initialized list.
 func New[T any]() *List[T] { return new(List[T]).Init() }
 
+// String returns the values of list l formatted with %+v and separated by
+// single spaces, or "" if the list is empty.
 func (l *List[T]) String() string {
-	strbuilder := &strings.Builder{}
-	if l.len == 0 {
-		return ""
-	}
-	n := l.root.next
-	for n != nil && n != &l.root {
-		strbuilder.WriteString(fmt.Sprintf("%+v ", n.Value))
-		n = n.next
+	return l.Join(" ", func(v T) string { return fmt.Sprintf("%+v", v) })
+}
+
+// Join returns the values of list l, each converted by format, separated
+// by sep. It returns "" if the list is empty.
+func (l *List[T]) Join(sep string, format func(T) string) string {
+	var b strings.Builder
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e != l.root.next {
+			b.WriteString(sep)
+		}
+		b.WriteString(format(e.Value))
 	}
-	return strbuilder.String()
+	return b.String()
 }
 
 // Len returns the number of elements of list l.
 // The complexity is O(1).
 func (l *List[T]) Len() int { return l.len }
 
+// IsEmpty reports whether list l has no elements.
+func (l *List[T]) IsEmpty() bool { return l.len == 0 }
+
 // Front returns the first element of list l or nil if the list is empty.
 func (l *List[T]) Front() *Element[T] {
 	if l.len == 0 {
@@ -170,6 +280,69 @@ func (l *List[T]) PushBack(v T) *Element[T] {
 	return l.insertValue(v, l.root.prev)
 }
 
+// PushBackAll inserts new elements with values vs at the back of list l,
+// in argument order.
+func (l *List[T]) PushBackAll(vs ...T) {
+	l.lazyInit()
+	for _, v := range vs {
+		l.insertValue(v, l.root.prev)
+	}
+}
+
+// PushFrontAll inserts new elements with values vs at the front of list l,
+// keeping argument order: afterwards l.Front() holds vs[0].
+func (l *List[T]) PushFrontAll(vs ...T) {
+	l.lazyInit()
+	at := &l.root
+	for _, v := range vs {
+		at = l.insertValue(v, at)
+	}
+}
+
+// PopFront removes the first element of list l and returns its value and
+// true, or the zero value and false if the list is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	e := l.root.next
+	l.remove(e)
+	return e.Value, true
+}
+
+// PopBack removes the last element of list l and returns its value and
+// true, or the zero value and false if the list is empty.
+func (l *List[T]) PopBack() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	e := l.root.prev
+	l.remove(e)
+	return e.Value, true
+}
+
+// PeekFront returns the value of the first element of list l and true,
+// or the zero value and false if the list is empty.
+func (l *List[T]) PeekFront() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return l.root.next.Value, true
+}
+
+// PeekBack returns the value of the last element of list l and true,
+// or the zero value and false if the list is empty.
+func (l *List[T]) PeekBack() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return l.root.prev.Value, true
+}
+
 // InsertBefore inserts a new element e with value v immediately before mark and returns e.
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
@@ -234,6 +407,36 @@ func (l *List[T]) MoveAfter(e, mark *Element[T]) {
 	l.move(e, mark)
 }
 
+// Swap exchanges the positions of elements e1 and e2 in list l.
+// If e1 or e2 is not an element of l, or e1 == e2, the list is not modified.
+// The elements must not be nil.
+func (l *List[T]) Swap(e1, e2 *Element[T]) {
+	if e1.list != l || e2.list != l || e1 == e2 {
+		return
+	}
+	swap(e1, e2)
+}
+
+// MoveToIndex moves element e so that it ends up at zero-based index i of
+// list l, with i clamped to [0, l.Len()-1]. If e is not an element of l,
+// the list is not modified. The element must not be nil.
+func (l *List[T]) MoveToIndex(e *Element[T], i int) {
+	if e.list != l {
+		return
+	}
+	i = max(0, min(i, l.len-1))
+	cur := 0
+	for p := l.root.next; p != e; p = p.next {
+		cur++
+	}
+	switch {
+	case i > cur:
+		l.MoveAfter(e, l.At(i))
+	case i < cur:
+		l.MoveBefore(e, l.At(i))
+	}
+}
+
 // PushBackList inserts a copy of another list at the back of list l.
 // The lists l and other may be the same. They must not be nil.
 func (l *List[T]) PushBackList(other *List[T]) {
@@ -252,83 +455,1911 @@ func (l *List[T]) PushFrontList(other *List[T]) {
 	}
 }
 
-func (l *List[T]) QuickSort(cmp func(a, b T) int) {
-	l.lazyInit()
-	first := l.Front()
-	last := l.Back()
-	_qsort(l, first, last, cmp)
+// Clone returns a new list holding the values of list l in the same
+// order. Values are copied by assignment.
+func (l *List[T]) Clone() *List[T] {
+	out := New[T]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		out.insertValue(e.Value, out.root.prev)
+	}
+	return out
+}
+
+// CloneFunc returns a new list holding copyValue applied to each value of
+// list l, in the same order. It allows deep copies of values such as
+// pointers, slices or maps.
+func (l *List[T]) CloneFunc(copyValue func(T) T) *List[T] {
+	return Map(l, copyValue)
+}
+
+// Apply replaces the value of each element of list l, from front to back,
+// with f applied to it. The elements themselves are not moved, so
+// existing element handles observe the new values.
+func (l *List[T]) Apply(f func(T) T) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		e.Value = f(e.Value)
+	}
+}
+
+// Transform calls fn for each element of list l, from front to back.
+// fn returns the replacement value and whether to keep the element;
+// if keep is false the element is removed, otherwise its Value is set
+// to the returned value. It returns the number of elements removed.
+func (l *List[T]) Transform(fn func(v T) (T, bool)) int {
+	removed := 0
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if v, keep := fn(e.Value); keep {
+			e.Value = v
+		} else {
+			l.remove(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// Map returns a new list holding f applied to each value of list l, in
+// order. It is a function rather than a method because methods cannot
+// have type parameters of their own. The list l is not modified.
+func Map[T, U any](l *List[T], f func(T) U) *List[U] {
+	out := New[U]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		out.insertValue(f(e.Value), out.root.prev)
+	}
+	return out
+}
+
+// Reduce folds the values of list l, from front to back, into an
+// accumulator that starts as init and is replaced by f(acc, v) for each
+// value v, and returns the final accumulator. Like Map, it is a function
+// because methods cannot have type parameters of their own.
+func Reduce[T, R any](l *List[T], init R, f func(acc R, v T) R) R {
+	acc := init
+	for e := l.Front(); e != nil; e = e.Next() {
+		acc = f(acc, e.Value)
+	}
+	return acc
+}
+
+// FlatMap returns a new list holding, in order, every value returned by
+// f for each element of l, from front to back. A nil or empty result
+// from f contributes nothing. The list l is not modified.
+func FlatMap[T, U any](l *List[T], f func(T) []U) *List[U] {
+	out := New[U]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		for _, v := range f(e.Value) {
+			out.insertValue(v, out.root.prev)
+		}
+	}
+	return out
+}
+
+// Min returns the first element of list l holding the smallest value
+// according to cmp, and true, or nil and false if l is empty.
+func (l *List[T]) Min(cmp func(a, b T) int) (*Element[T], bool) {
+	best := l.Front()
+	if best == nil {
+		return nil, false
+	}
+	for e := best.Next(); e != nil; e = e.Next() {
+		if cmp(e.Value, best.Value) < 0 {
+			best = e
+		}
+	}
+	return best, true
+}
+
+// Max returns the first element of list l holding the largest value
+// according to cmp, and true, or nil and false if l is empty.
+func (l *List[T]) Max(cmp func(a, b T) int) (*Element[T], bool) {
+	return l.Min(func(a, b T) int { return cmp(b, a) })
+}
+
+// MinBy returns the first element of list l whose key(v) is smallest, and
+// true, or nil and false if l is empty. key is called once per element.
+func MinBy[T any, K cmp.Ordered](l *List[T], key func(T) K) (*Element[T], bool) {
+	return extremeBy(l, key, -1)
+}
+
+// MaxBy returns the first element of list l whose key(v) is largest, and
+// true, or nil and false if l is empty. key is called once per element.
+func MaxBy[T any, K cmp.Ordered](l *List[T], key func(T) K) (*Element[T], bool) {
+	return extremeBy(l, key, +1)
+}
+
+// extremeBy implements MinBy (sign -1) and MaxBy (sign +1).
+func extremeBy[T any, K cmp.Ordered](l *List[T], key func(T) K, sign int) (*Element[T], bool) {
+	best := l.Front()
+	if best == nil {
+		return nil, false
+	}
+	bestKey := key(best.Value)
+	for e := best.Next(); e != nil; e = e.Next() {
+		if k := key(e.Value); cmp.Compare(k, bestKey) == sign {
+			best, bestKey = e, k
+		}
+	}
+	return best, true
+}
+
+// Nearest returns the element of l whose value minimizes dist(value, target)
+// and true, or nil and false if l is empty. Ties resolve to the element
+// nearest the front.
+func (l *List[T]) Nearest(target T, dist func(a, b T) float64) (*Element[T], bool) {
+	best := l.Front()
+	if best == nil {
+		return nil, false
+	}
+	bestDist := dist(best.Value, target)
+	for e := best.Next(); e != nil; e = e.Next() {
+		if d := dist(e.Value, target); d < bestDist {
+			best, bestDist = e, d
+		}
+	}
+	return best, true
+}
+
+// Divide splits a copy of list l into n new lists whose concatenation
+// equals l and whose lengths differ by at most one; the longer parts come
+// first. It always returns n lists, so if n > l.Len() the trailing parts
+// are empty. The list l is not modified. Divide panics if n <= 0.
+func (l *List[T]) Divide(n int) []*List[T] {
+	if n <= 0 {
+		panic("list: Divide called with n <= 0")
+	}
+	parts := make([]*List[T], n)
+	size, extra := l.Len()/n, l.Len()%n
+	e := l.Front()
+	for i := range parts {
+		p := New[T]()
+		k := size
+		if i < extra {
+			k++
+		}
+		for ; k > 0; k-- {
+			p.insertValue(e.Value, p.root.prev)
+			e = e.Next()
+		}
+		parts[i] = p
+	}
+	return parts
+}
+
+// RunningExtremaFunc returns an iterator that yields, for each element of
+// list l from front to back, the smallest and largest values seen so far
+// according to cmp. An empty list yields nothing.
+func (l *List[T]) RunningExtremaFunc(cmp func(a, b T) int) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		e := l.Front()
+		if e == nil {
+			return
+		}
+		lo, hi := e.Value, e.Value
+		for e != nil {
+			next := e.Next()
+			if cmp(e.Value, lo) < 0 {
+				lo = e.Value
+			}
+			if cmp(e.Value, hi) > 0 {
+				hi = e.Value
+			}
+			if !yield(lo, hi) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Sorted returns a new list holding the values of l sorted by cmp.
+// The sort is stable, and the list l is not modified.
+func (l *List[T]) Sorted(cmp func(a, b T) int) *List[T] {
+	out := l.Clone()
+	out.mergeSort(cmp)
+	return out
+}
+
+// Select returns the k-th smallest value (counting from 0) of list l
+// according to cmp, and true. If k is out of range it returns the zero
+// value and false. It runs a quickselect over a copy of the values in
+// expected O(n) time; the list l is not modified.
+func (l *List[T]) Select(k int, cmp func(a, b T) int) (T, bool) {
+	if k < 0 || k >= l.Len() {
+		var zero T
+		return zero, false
+	}
+	s := l.ToSlice()
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		// Three-way partition s[lo:hi+1] into < pivot, == pivot, > pivot.
+		pivot := s[lo+(hi-lo)/2]
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch c := cmp(s[i], pivot); {
+			case c < 0:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case c > 0:
+				s[i], s[gt] = s[gt], s[i]
+				gt--
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return s[k], true
+		}
+	}
+	return s[k], true
 }
 
-func _qsort[T any](lst *List[T], left, right *Element[T], cmp func(a, b T) int) {
-	if left == right {
+// RotateValues shifts every value of list l n positions toward the back,
+// wrapping values that pass the back around to the front; a negative n
+// shifts toward the front. The elements themselves stay in place, so an
+// element at a fixed position observes the new value for that position.
+// n is reduced modulo l.Len().
+func (l *List[T]) RotateValues(n int) {
+	if l.len == 0 {
 		return
 	}
-	// LBoundary and RBoundary are boundaries before left and after right
-	LBoundary := left.prev
-	RBoundary := right.next
-	pivotValue := left.Value
-	var finalPivot *Element[T] = nil
-	for {
-		// Right moves first, and finally finds a value within the [left, right] interval that is<left or==left (only when it coincides with left)
-		for right != left && cmp(right.Value, pivotValue) >= 0 {
-			right = right.Prev()
+	n %= l.len
+	if n < 0 {
+		n += l.len
+	}
+	if n == 0 {
+		return
+	}
+	s := l.ToSlice()
+	i := l.len - n
+	for e := l.Front(); e != nil; e = e.Next() {
+		e.Value = s[i]
+		if i++; i == l.len {
+			i = 0
+		}
+	}
+}
+
+// GroupConsecutive splits a copy of list l into runs of adjacent elements
+// whose values share the same key, returning one new list per run in
+// order. Only adjacent elements are grouped: a key that reappears after
+// a different key starts a new run. The list l is not modified.
+func GroupConsecutive[T any, K comparable](l *List[T], key func(T) K) []*List[T] {
+	var groups []*List[T]
+	var cur *List[T]
+	var curKey K
+	for e := l.Front(); e != nil; e = e.Next() {
+		if k := key(e.Value); cur == nil || k != curKey {
+			cur, curKey = New[T](), k
+			groups = append(groups, cur)
+		}
+		cur.insertValue(e.Value, cur.root.prev)
+	}
+	return groups
+}
+
+// FindElementAndIndex returns the first element of list l whose value
+// satisfies pred, together with its zero-based index, or nil and -1 if
+// there is none.
+func (l *List[T]) FindElementAndIndex(pred func(T) bool) (*Element[T], int) {
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			return e, i
+		}
+		i++
+	}
+	return nil, -1
+}
+
+// SortByKeyCached stably sorts list l in ascending order of key(v),
+// calling key exactly once per element. It is preferable to a
+// comparator-based sort when key is expensive to compute.
+// The elements are relinked, so existing element handles remain valid.
+func SortByKeyCached[T any, K cmp.Ordered](l *List[T], key func(T) K) {
+	type keyed struct {
+		e *Element[T]
+		k K
+	}
+	s := make([]keyed, 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		s = append(s, keyed{e, key(e.Value)})
+	}
+	slices.SortStableFunc(s, func(a, b keyed) int { return cmp.Compare(a.k, b.k) })
+	es := make([]*Element[T], len(s))
+	for i := range s {
+		es[i] = s[i].e
+	}
+	l.relink(es)
+}
+
+// Drain returns an iterator that removes the front element of list l and
+// yields its value until l is empty. The list shrinks as iteration
+// proceeds: if the loop stops early, the values already yielded have
+// been removed and the rest remain in l.
+func (l *List[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; e = l.Front() {
+			l.remove(e)
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// GroupMatchingToFront stably partitions list l in place: elements whose
+// values satisfy pred are moved ahead of those that do not, and both
+// groups keep their original relative order. Elements are relinked, not
+// copied, so existing element handles remain valid.
+func (l *List[T]) GroupMatchingToFront(pred func(T) bool) {
+	mark := &l.root // last element of the matching group
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if pred(e.Value) {
+			if mark.next != e {
+				l.move(e, mark)
+			}
+			mark = e
 		}
-		// Then move left and finally find a value within the [left, right] interval that is>left or==left (only when it coincides with left)
-		for left != right && cmp(left.Value, pivotValue) <= 0 {
-			left = left.Next()
+		e = next
+	}
+}
+
+// WellFormed checks the structural invariants of list l and returns an
+// error describing the first one violated, or nil. It verifies that
+// walking next from the sentinel returns to it in exactly l.Len() steps,
+// that walking prev does too, that every element belongs to l, and that
+// next and prev are inverses of each other. A zero List is well formed.
+func (l *List[T]) WellFormed() error {
+	if l.root.next == nil && l.root.prev == nil {
+		if l.len != 0 {
+			return fmt.Errorf("list: uninitialized list has length %d", l.len)
+		}
+		return nil
+	}
+	n := 0
+	for e := &l.root; ; {
+		if e.next == nil {
+			return fmt.Errorf("list: element %d has nil next pointer", n)
 		}
-		if left == right {
+		if e.next.prev != e {
+			return fmt.Errorf("list: element %d is not the prev of its next", n)
+		}
+		if e = e.next; e == &l.root {
 			break
 		}
-		isNeighbour := swap(left, right)
-		left, right = right, left
-		if isNeighbour { // When adjacent, there is no need for the next loop. In fact, there can be no break. The next time left and right are equal, there will also be a break
+		if n++; n > l.len {
+			return fmt.Errorf("list: forward walk exceeds length %d", l.len)
+		}
+		if e.list != l {
+			return fmt.Errorf("list: element %d does not belong to the list", n-1)
+		}
+	}
+	if n != l.len {
+		return fmt.Errorf("list: forward walk took %d steps, want %d", n, l.len)
+	}
+	n = 0
+	for e := &l.root; ; {
+		if e.prev == nil {
+			return fmt.Errorf("list: element %d from the back has nil prev pointer", n)
+		}
+		if e.prev.next != e {
+			return fmt.Errorf("list: element %d from the back is not the next of its prev", n)
+		}
+		if e = e.prev; e == &l.root {
 			break
 		}
+		if n++; n > l.len {
+			return fmt.Errorf("list: backward walk exceeds length %d", l.len)
+		}
 	}
-	// After the loop ends, the position of left is the right boundary of all values less than or equal to pivotValue
-	// Place pivotValue in the left position
-	leftNext := left.next // Using the next position of the left, swapping and then taking the previous one is the final pivot position
-	swap(LBoundary.next, left)
-	finalPivot = leftNext.prev
-	if LBoundary.next != finalPivot { // It may overlap to one point. Next time, recursion will occur, they cross over and loop to the wrong side and never ends.
-		_qsort(lst, LBoundary.next, finalPivot.prev, cmp)
+	if n != l.len {
+		return fmt.Errorf("list: backward walk took %d steps, want %d", n, l.len)
 	}
-	if finalPivot != RBoundary.prev { // Same as above
-		_qsort(lst, finalPivot.next, RBoundary.prev, cmp)
+	return nil
+}
+
+// ToMap returns a map from key(v) to v for every value v of list l.
+// When several values share a key, the one nearest the back wins.
+func ToMap[T any, K comparable](l *List[T], key func(T) K) map[K]T {
+	m := make(map[K]T, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		m[key(e.Value)] = e.Value
 	}
+	return m
 }
-func swap[T any](b, d *Element[T]) (neighbor bool) {
-	if b != d {
-		if b.next == d || b.prev == d { // are neighours
-			if b.next == d { // a <=> b <=> d <=> e
-				b.prev.next = d
-				d.next.prev = b
-				d.prev = b.prev
-				b.next = d.next
-				d.next = b
-				b.prev = d
-			} else { // a <=> d <=> b <=> e
-				d.prev.next = b
-				b.next.prev = d
-				b.prev = d.prev
-				d.next = b.next
-				b.next = d
-				d.prev = b
-			}
-			neighbor = true
-		} else { // a <=> b <=> c <=> d <=> e
-			b.next.prev = d
-			b.prev.next = d
-			d.next.prev = b
-			d.prev.next = b
-			bPrevP := b.prev
-			bNextP := b.next
-			b.prev = d.prev
-			b.next = d.next
-			d.prev = bPrevP
-			d.next = bNextP
+
+// ToMapE is like ToMap but returns an error if two values of list l
+// share a key.
+func ToMapE[T any, K comparable](l *List[T], key func(T) K) (map[K]T, error) {
+	m := make(map[K]T, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		k := key(e.Value)
+		if _, dup := m[k]; dup {
+			return nil, fmt.Errorf("list: duplicate key %v", k)
 		}
+		m[k] = e.Value
 	}
-	return
+	return m, nil
+}
+
+// SendTo sends every value of list l, from front to back, on ch and
+// returns once all have been sent. It does not close ch. Each send
+// blocks until ch can accept the value, so a slow receiver applies
+// backpressure to the caller.
+func (l *List[T]) SendTo(ch chan<- T) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		ch <- e.Value
+	}
+}
+
+// DrainChannel receives values from ch until it is closed and returns
+// them, in the order received, as a new list.
+func DrainChannel[T any](ch <-chan T) *List[T] {
+	l := New[T]()
+	for v := range ch {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// MergeSortedInto moves every element of other into list l, assuming both
+// are sorted in ascending order by cmp, so that l remains sorted and other
+// becomes empty. It makes a single O(n+m) pass and allocates nothing;
+// elements are relinked, so existing element handles remain valid and
+// now belong to l. On ties the elements of l come first. If other == l,
+// the list is not modified.
+func (l *List[T]) MergeSortedInto(other *List[T], cmp func(a, b T) int) {
+	if other == l || other.Len() == 0 {
+		return
+	}
+	l.lazyInit()
+	at := l.root.next
+	for o := other.root.next; o != &other.root; {
+		next := o.next
+		for at != &l.root && cmp(at.Value, o.Value) <= 0 {
+			at = at.next
+		}
+		// Link o immediately before at.
+		o.prev = at.prev
+		o.next = at
+		at.prev.next = o
+		at.prev = o
+		o.list = l
+		o = next
+	}
+	l.len += other.len
+	other.Init()
+}
+
+// Merge moves every element of other into list l, assuming both are sorted
+// in ascending order by cmp, so that l remains sorted and other becomes
+// empty. It is equivalent to MergeSortedInto.
+func (l *List[T]) Merge(other *List[T], cmp func(a, b T) int) {
+	l.MergeSortedInto(other, cmp)
+}
+
+// Inversions returns the number of pairs of positions i < j in list l
+// whose values satisfy cmp(v_i, v_j) > 0; it is zero exactly when l is
+// sorted by cmp. It counts with a merge sort over a copy of the values
+// in O(n log n) time; the list l is not modified.
+func (l *List[T]) Inversions(cmp func(a, b T) int) int {
+	s := l.ToSlice()
+	return countInversions(s, make([]T, len(s)), cmp)
+}
+
+// countInversions sorts s by cmp, using buf as scratch space of the same
+// length, and returns the number of inversions it removed.
+func countInversions[T any](s, buf []T, cmp func(a, b T) int) int {
+	if len(s) < 2 {
+		return 0
+	}
+	mid := len(s) / 2
+	n := countInversions(s[:mid], buf[:mid], cmp) + countInversions(s[mid:], buf[mid:], cmp)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(s) {
+		if cmp(s[i], s[j]) <= 0 {
+			buf[k] = s[i]
+			i++
+		} else {
+			// s[j] is smaller than every value remaining in the left half.
+			n += mid - i
+			buf[k] = s[j]
+			j++
+		}
+		k++
+	}
+	k += copy(buf[k:], s[i:mid])
+	copy(buf[k:], s[j:])
+	copy(s, buf)
+	return n
+}
+
+// insertSorted inserts v into list l, which must be sorted in ascending
+// order by cmp, after any values equal to it, and returns the new element.
+func (l *List[T]) insertSorted(v T, cmp func(a, b T) int) *Element[T] {
+	l.lazyInit()
+	at := l.root.prev
+	for at != &l.root && cmp(at.Value, v) > 0 {
+		at = at.prev
+	}
+	return l.insertValue(v, at)
+}
+
+// A MedianTracker maintains the running median of a stream of values.
+// It keeps the lower and upper halves of the values seen so far in two
+// sorted lists, so Median is O(1) while Add costs O(n) for the sorted
+// insertion, unlike the O(log n) of a heap-based tracker.
+// The zero value has no comparison function and is not usable; a
+// MedianTracker must be created with NewMedianTracker.
+type MedianTracker[T any] struct {
+	low, high List[T] // low holds the smaller half and is never shorter than high
+	cmp       func(a, b T) int
+}
+
+// NewMedianTracker returns an empty MedianTracker ordering values by cmp.
+func NewMedianTracker[T any](cmp func(a, b T) int) *MedianTracker[T] {
+	return &MedianTracker[T]{cmp: cmp}
+}
+
+// Len returns the number of values added to m.
+func (m *MedianTracker[T]) Len() int { return m.low.Len() + m.high.Len() }
+
+// Add adds v to the values tracked by m.
+func (m *MedianTracker[T]) Add(v T) {
+	if m.low.Len() == 0 || m.cmp(v, m.low.Back().Value) <= 0 {
+		m.low.insertSorted(v, m.cmp)
+	} else {
+		m.high.insertSorted(v, m.cmp)
+	}
+	// Rebalance by moving a single element across the boundary.
+	switch {
+	case m.low.Len() > m.high.Len()+1:
+		e := m.low.Back()
+		m.low.remove(e)
+		m.high.lazyInit()
+		m.high.insert(e, &m.high.root)
+	case m.high.Len() > m.low.Len():
+		e := m.high.Front()
+		m.high.remove(e)
+		m.low.insert(e, m.low.root.prev)
+	}
+}
+
+// Median returns the median of the values added to m and true, or the
+// zero value and false if none have been added. For an even number of
+// values it returns the lower of the two middle values.
+func (m *MedianTracker[T]) Median() (T, bool) {
+	if m.low.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return m.low.Back().Value, true
+}
+
+// DistinctFunc removes from list l every element whose key(v) equals that
+// of an earlier element, keeping the first occurrence of each key and the
+// order of the survivors. It returns the number of elements removed.
+func DistinctFunc[T any, K comparable](l *List[T], key func(T) K) int {
+	seen := make(map[K]struct{})
+	removed := 0
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		k := key(e.Value)
+		if _, dup := seen[k]; dup {
+			l.remove(e)
+			removed++
+		} else {
+			seen[k] = struct{}{}
+		}
+		e = next
+	}
+	return removed
+}
+
+// Reverse reverses the order of the elements of list l in place by
+// exchanging the next and prev pointers of every element. No elements
+// are allocated, and existing element handles remain valid.
+func (l *List[T]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		if e = e.prev; e == &l.root {
+			break
+		}
+	}
+}
+
+// RemoveValue removes the first element of list l whose value equals v
+// and reports whether one was found.
+func RemoveValue[T comparable](l *List[T], v T) bool {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value == v {
+			l.remove(e)
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAllValue removes every element of list l whose value equals v
+// and returns the number of elements removed.
+func RemoveAllValue[T comparable](l *List[T], v T) int {
+	removed := 0
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if e.Value == v {
+			l.remove(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// LongestRun returns the first element and the length of the longest
+// contiguous run of list l in which each value is cmp-greater than or
+// equal to the one before it. It returns nil and 0 for an empty list;
+// if several runs are equally long, the one nearest the front wins.
+func (l *List[T]) LongestRun(cmp func(a, b T) int) (start *Element[T], length int) {
+	var cur *Element[T]
+	n := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if cur == nil || cmp(e.prev.Value, e.Value) > 0 {
+			cur, n = e, 0
+		}
+		if n++; n > length {
+			start, length = cur, n
+		}
+	}
+	return start, length
+}
+
+// A Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Product returns a new list holding a Pair for every combination of a
+// value of a and a value of b, in row-major order: all pairs for the
+// front of a come first. The result has a.Len()*b.Len() elements, and is
+// empty if either input is.
+func Product[A, B any](a *List[A], b *List[B]) *List[Pair[A, B]] {
+	return ProductWith(a, b, func(x A, y B) Pair[A, B] { return Pair[A, B]{x, y} })
+}
+
+// ProductWith is like Product but stores f(x, y) for each combination
+// instead of a Pair.
+func ProductWith[A, B, C any](a *List[A], b *List[B], f func(A, B) C) *List[C] {
+	out := New[C]()
+	for x := a.Front(); x != nil; x = x.Next() {
+		for y := b.Front(); y != nil; y = y.Next() {
+			out.insertValue(f(x.Value, y.Value), out.root.prev)
+		}
+	}
+	return out
+}
+
+// ConcatSeq returns an iterator over the values of each of lists in
+// turn, from front to back, without building a combined list. Nil lists
+// are skipped.
+func ConcatSeq[T any](lists ...*List[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, l := range lists {
+			if l == nil {
+				continue
+			}
+			for e := l.Front(); e != nil; {
+				next := e.Next()
+				if !yield(e.Value) {
+					return
+				}
+				e = next
+			}
+		}
+	}
+}
+
+// Equal reports whether lists a and b have the same length and equal
+// values in the same order.
+func Equal[T comparable](a, b *List[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc reports whether lists a and b have the same length and
+// values that are equal according to eq, pairwise in order.
+func EqualFunc[T any](a, b *List[T], eq func(T, T) bool) bool {
+	return a.Len() == b.Len() && DiffIndex(a, b, eq) < 0
+}
+
+// DiffIndex returns the index of the first position at which lists a and
+// b differ according to eq, or -1 if they are equal. If one list is a
+// prefix of the other, it returns the length of the shorter one.
+func DiffIndex[T any](a, b *List[T], eq func(x, y T) bool) int {
+	i := 0
+	x, y := a.Front(), b.Front()
+	for ; x != nil && y != nil; x, y = x.Next(), y.Next() {
+		if !eq(x.Value, y.Value) {
+			return i
+		}
+		i++
+	}
+	if x != nil || y != nil {
+		return i
+	}
+	return -1
+}
+
+// Resize makes list l exactly n elements long, removing elements from the
+// back if it is longer and appending copies of fill if it is shorter. A
+// negative n is treated as 0. The remaining elements are not modified.
+func (l *List[T]) Resize(n int, fill T) {
+	l.lazyInit()
+	for l.len > n && l.len > 0 {
+		l.remove(l.root.prev)
+	}
+	for l.len < n {
+		l.insertValue(fill, l.root.prev)
+	}
+}
+
+// Reposition moves element e to its sorted position in list l, assuming
+// all other elements of l are sorted in ascending order by cmp. It is
+// O(1) if e is already in place and O(n) in the worst case. If e is not
+// an element of l, the list is not modified. The element must not be nil.
+func (l *List[T]) Reposition(e *Element[T], cmp func(a, b T) int) {
+	if e.list != l {
+		return
+	}
+	at := e.prev
+	for at != &l.root && cmp(at.Value, e.Value) > 0 {
+		at = at.prev
+	}
+	if at != e.prev {
+		l.move(e, at)
+		return
+	}
+	at = e.next
+	for at != &l.root && cmp(at.Value, e.Value) < 0 {
+		at = at.next
+	}
+	if at != e.next {
+		l.move(e, at.prev)
+	}
+}
+
+// A PeekIter iterates over the values of a list, from front to back,
+// with one value of lookahead.
+type PeekIter[T any] struct {
+	next *Element[T] // element whose value Next returns, or nil
+}
+
+// PeekIter returns a PeekIter positioned at the front of list l.
+func (l *List[T]) PeekIter() *PeekIter[T] {
+	return &PeekIter[T]{next: l.Front()}
+}
+
+// Next returns the next value and true, advancing the iterator, or the
+// zero value and false if the values are exhausted.
+func (it *PeekIter[T]) Next() (T, bool) {
+	if it.next == nil {
+		var zero T
+		return zero, false
+	}
+	e := it.next
+	it.next = e.Next()
+	return e.Value, true
+}
+
+// Peek returns the value that Next would return, without advancing the
+// iterator, or the zero value and false if the values are exhausted.
+func (it *PeekIter[T]) Peek() (T, bool) {
+	if it.next == nil {
+		var zero T
+		return zero, false
+	}
+	return it.next.Value, true
+}
+
+// MergeKUnique merges lists, each sorted in ascending order by cmp, into
+// a new sorted list holding one value for each run of values that compare
+// equal, both within and across the inputs. Of equal values, the one kept
+// is the first in the earliest list. It runs in O(N log K) time for N
+// values in K lists; the input lists are not modified, and nil lists are
+// skipped.
+func MergeKUnique[T any](cmp func(a, b T) int, lists ...*List[T]) *List[T] {
+	h := &mergeHeap[T]{cmp: cmp}
+	for i, l := range lists {
+		if l != nil && l.Len() > 0 {
+			h.items = append(h.items, mergeItem[T]{l.Front(), i})
+		}
+	}
+	heap.Init(h)
+	out := New[T]()
+	for len(h.items) > 0 {
+		it := &h.items[0]
+		if out.len == 0 || cmp(out.root.prev.Value, it.e.Value) != 0 {
+			out.insertValue(it.e.Value, out.root.prev)
+		}
+		if it.e = it.e.Next(); it.e != nil {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return out
+}
+
+// mergeItem is the current element of one input list of MergeKUnique.
+type mergeItem[T any] struct {
+	e   *Element[T]
+	src int // index of the input list, to break ties
+}
+
+// mergeHeap is a min-heap of mergeItems ordered by value, then source.
+type mergeHeap[T any] struct {
+	items []mergeItem[T]
+	cmp   func(a, b T) int
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.items) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.items[i].e.Value, h.items[j].e.Value); c != 0 {
+		return c < 0
+	}
+	return h.items[i].src < h.items[j].src
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.items = append(h.items, x.(mergeItem[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	it := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return it
+}
+
+// A SyncList is a List guarded by a read-write mutex, so that it can be
+// shared between goroutines. Only the methods declared on SyncList take the
+// lock; the other List methods promoted through the embedded *List, and
+// any access through an *Element (its Value, Next, Prev and so on), are
+// not synchronized and must be guarded by the caller holding the mutex.
+// The zero value for SyncList is an empty list ready to use; its List is
+// allocated by the first method that needs it.
+type SyncList[T any] struct {
+	sync.RWMutex
+	*List[T]
+}
+
+// NewSyncList returns an initialized, empty SyncList.
+func NewSyncList[T any]() *SyncList[T] {
+	return &SyncList[T]{List: New[T]()}
+}
+
+// lazyList returns the list of s, allocating it if s is the zero value.
+// The caller must hold the write lock.
+func (s *SyncList[T]) lazyList() *List[T] {
+	if s.List == nil {
+		s.List = New[T]()
+	}
+	return s.List
+}
+
+// PushBack inserts a new element e with value v at the back of list s and returns e.
+func (s *SyncList[T]) PushBack(v T) *Element[T] {
+	s.Lock()
+	defer s.Unlock()
+	return s.lazyList().PushBack(v)
+}
+
+// PushFront inserts a new element e with value v at the front of list s and returns e.
+func (s *SyncList[T]) PushFront(v T) *Element[T] {
+	s.Lock()
+	defer s.Unlock()
+	return s.lazyList().PushFront(v)
+}
+
+// PopFront removes the first element of list s and returns its value.
+// If s is empty, PopFront returns the zero value and false.
+func (s *SyncList[T]) PopFront() (T, bool) {
+	s.Lock()
+	defer s.Unlock()
+	return s.lazyList().PopFront()
+}
+
+// PopBack removes the last element of list s and returns its value.
+// If s is empty, PopBack returns the zero value and false.
+func (s *SyncList[T]) PopBack() (T, bool) {
+	s.Lock()
+	defer s.Unlock()
+	return s.lazyList().PopBack()
+}
+
+// Len returns the number of elements of list s.
+func (s *SyncList[T]) Len() int {
+	s.RLock()
+	defer s.RUnlock()
+	if s.List == nil {
+		return 0
+	}
+	return s.List.Len()
+}
+
+// Remove removes e from s if e is an element of list s.
+// It returns the element value e.Value.
+func (s *SyncList[T]) Remove(e *Element[T]) T {
+	s.Lock()
+	defer s.Unlock()
+	return s.lazyList().Remove(e)
+}
+
+// Range calls f for each value of list s from front to back, holding the
+// read lock throughout. If f returns false, Range stops the iteration.
+// f must not call any method of s, since the read lock is not reentrant.
+func (s *SyncList[T]) Range(f func(v T) bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.List == nil {
+		return
+	}
+	for e := s.List.Front(); e != nil; e = e.Next() {
+		if !f(e.Value) {
+			return
+		}
+	}
+}
+
+// An IndexError reports a position outside the bounds of a list.
+type IndexError struct {
+	Index int // the offending index
+	Len   int // the length of the list at the time
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("list: index %d out of range for list of length %d", e.Index, e.Len)
+}
+
+// At returns the element at zero-based index i of list l, or nil if i is
+// out of range. It walks from whichever end of the list is nearer, so the
+// complexity is O(min(i, l.Len()-i)).
+func (l *List[T]) At(i int) *Element[T] {
+	if i < 0 || i >= l.len {
+		return nil
+	}
+	if i < l.len/2 {
+		e := l.root.next
+		for ; i > 0; i-- {
+			e = e.next
+		}
+		return e
+	}
+	e := l.root.prev
+	for i = l.len - 1 - i; i > 0; i-- {
+		e = e.prev
+	}
+	return e
+}
+
+// GetE returns the element at zero-based index i of list l, or an
+// *IndexError if i is out of range.
+func (l *List[T]) GetE(i int) (*Element[T], error) {
+	e := l.At(i)
+	if e == nil {
+		return nil, &IndexError{Index: i, Len: l.len}
+	}
+	return e, nil
+}
+
+// ClearWithStats removes all elements from list l and returns how many
+// there were. Each removed element is detached and its Value is set to
+// the zero value, so that large values it referenced can be collected
+// even while handles to the element are still held.
+func (l *List[T]) ClearWithStats() int {
+	n := l.len
+	var zero T
+	for e := l.Front(); e != nil; e = e.Next() {
+		e.Value = zero
+	}
+	l.Clear()
+	return n
+}
+
+// PartitionAround stably rearranges list l in place so that the elements
+// whose values are less than pivot according to cmp come first, followed
+// by those equal to pivot and then those greater. It returns the first
+// element not less than pivot, or nil if there is none. Elements are
+// relinked, so existing element handles remain valid.
+func (l *List[T]) PartitionAround(pivot T, cmp func(a, b T) int) *Element[T] {
+	var less, equal, greater []*Element[T]
+	for e := l.Front(); e != nil; e = e.Next() {
+		switch c := cmp(e.Value, pivot); {
+		case c < 0:
+			less = append(less, e)
+		case c == 0:
+			equal = append(equal, e)
+		default:
+			greater = append(greater, e)
+		}
+	}
+	es := append(append(less, equal...), greater...)
+	l.relink(es)
+	if len(less) == len(es) {
+		return nil
+	}
+	return es[len(less)]
+}
+
+// Generate returns a new list holding the values returned by successive
+// calls to next, stopping at the first call that returns false.
+func Generate[T any](next func() (T, bool)) *List[T] {
+	l := New[T]()
+	for v, ok := next(); ok; v, ok = next() {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// ToSlice returns a new slice holding the values of list l from front to
+// back. For an empty list it returns an empty, non-nil slice.
+func (l *List[T]) ToSlice() []T {
+	s := make([]T, 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		s = append(s, e.Value)
+	}
+	return s
+}
+
+// FromSlice returns a new list holding the values of s in order, so that
+// the front of the list holds s[0]. A nil or empty s yields an empty list.
+func FromSlice[T any](s []T) *List[T] {
+	l := New[T]()
+	for _, v := range s {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// All returns an iterator over the zero-based indexes and values of the
+// elements of list l, from front to back.
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(i, e.Value) {
+				return
+			}
+			e = next
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over the zero-based indexes and values of
+// the elements of list l, from back to front, so indexes count down from
+// l.Len()-1 to 0.
+func (l *List[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.Len() - 1
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(i, e.Value) {
+				return
+			}
+			e = prev
+			i--
+		}
+	}
+}
+
+// Values returns an iterator over the values of list l, from front to back.
+func (l *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e.Value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Collect returns a new list holding the values of seq in order.
+func Collect[T any](seq iter.Seq[T]) *List[T] {
+	l := New[T]()
+	for v := range seq {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// RemoveFunc removes every element of list l whose value satisfies pred
+// and returns the number of elements removed. The remaining elements keep
+// their relative order.
+func (l *List[T]) RemoveFunc(pred func(T) bool) int {
+	removed := 0
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if pred(e.Value) {
+			l.remove(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// RetainFunc removes every element of list l whose value does not satisfy
+// pred and returns the number of elements removed. The remaining elements
+// keep their relative order.
+func (l *List[T]) RetainFunc(pred func(T) bool) int {
+	return l.RemoveFunc(func(v T) bool { return !pred(v) })
+}
+
+// ForEach calls f for the value of each element of list l, from front to
+// back.
+func (l *List[T]) ForEach(f func(T)) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		f(e.Value)
+	}
+}
+
+// ForEachElement calls f for each element of list l, from front to back,
+// stopping early if f returns false. f may remove the element it is
+// given.
+func (l *List[T]) ForEachElement(f func(e *Element[T]) bool) {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if !f(e) {
+			return
+		}
+		e = next
+	}
+}
+
+// Contains reports whether some element of list l has value v.
+func Contains[T comparable](l *List[T], v T) bool {
+	return IndexOf(l, v) >= 0
+}
+
+// IndexOf returns the zero-based index of the first element of list l
+// whose value equals v, or -1 if there is none.
+func IndexOf[T comparable](l *List[T], v T) int {
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value == v {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// ContainsFunc reports whether the value of some element of list l
+// satisfies pred.
+func (l *List[T]) ContainsFunc(pred func(T) bool) bool {
+	return l.IndexOfFunc(pred) >= 0
+}
+
+// IndexOfFunc returns the zero-based index of the first element of list l
+// whose value satisfies pred, or -1 if there is none.
+func (l *List[T]) IndexOfFunc(pred func(T) bool) int {
+	_, i := l.FindElementAndIndex(pred)
+	return i
+}
+
+// RemoveAt removes the element at zero-based index i of list l and returns
+// its value and true, or the zero value and false if i is out of range.
+func (l *List[T]) RemoveAt(i int) (T, bool) {
+	e := l.At(i)
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	l.remove(e)
+	return e.Value, true
+}
+
+// InsertAt inserts a new element e with value v so that it ends up at
+// zero-based index i of list l, and returns e. An i of l.Len() appends to
+// the back. If i is out of range, the list is not modified and InsertAt
+// returns nil.
+func (l *List[T]) InsertAt(i int, v T) *Element[T] {
+	if i == l.Len() {
+		return l.PushBack(v)
+	}
+	mark := l.At(i)
+	if mark == nil {
+		return nil
+	}
+	return l.InsertBefore(v, mark)
+}
+
+// Shuffle puts the elements of list l in a uniformly random order using
+// the Fisher-Yates algorithm with randomness from r, or from the default
+// source of math/rand if r is nil. Elements are relinked, so existing
+// element handles remain valid.
+func (l *List[T]) Shuffle(r *rand.Rand) {
+	es := make([]*Element[T], 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		es = append(es, e)
+	}
+	exchange := func(i, j int) { es[i], es[j] = es[j], es[i] }
+	if r != nil {
+		r.Shuffle(len(es), exchange)
+	} else {
+		rand.Shuffle(len(es), exchange)
+	}
+	l.relink(es)
+}
+
+// SplitAt cuts list l in two at zero-based index i: l keeps the elements
+// before index i and the returned new list holds the rest. An i <= 0
+// moves every element to the new list, and an i >= l.Len() returns an
+// empty list. Elements are relinked rather than copied, so existing
+// element handles remain valid and belong to their new list.
+func (l *List[T]) SplitAt(i int) *List[T] {
+	out := New[T]()
+	first := l.At(max(i, 0))
+	if first == nil {
+		return out
+	}
+	last := l.root.prev
+	n := 0
+	for e := first; e != &l.root; e = e.next {
+		e.list = out
+		n++
+	}
+	first.prev.next = &l.root
+	l.root.prev = first.prev
+	l.len -= n
+	first.prev = &out.root
+	last.next = &out.root
+	out.root.next = first
+	out.root.prev = last
+	out.len = n
+	return out
+}
+
+// Partition moves every element of list l into one of two new lists:
+// matched receives those whose values satisfy pred and rest the others,
+// each keeping the original relative order. Afterwards l is empty.
+// Elements are relinked rather than copied, so existing element handles
+// remain valid and belong to their new list.
+func (l *List[T]) Partition(pred func(T) bool) (matched, rest *List[T]) {
+	matched, rest = New[T](), New[T]()
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		l.remove(e)
+		if pred(e.Value) {
+			matched.insert(e, matched.root.prev)
+		} else {
+			rest.insert(e, rest.root.prev)
+		}
+		e = next
+	}
+	return matched, rest
+}
+
+// Concat moves every element of other to the back of list l, leaving other
+// empty. The rings are spliced in constant time, but each moved element
+// must be re-pointed at l, so the cost is O(other.Len()). Existing element
+// handles remain valid and belong to l. If other == l, the list is not
+// modified.
+func (l *List[T]) Concat(other *List[T]) {
+	if other == l || other.Len() == 0 {
+		return
+	}
+	l.lazyInit()
+	for e := other.root.next; e != &other.root; e = e.next {
+		e.list = l
+	}
+	first, last := other.root.next, other.root.prev
+	first.prev = l.root.prev
+	l.root.prev.next = first
+	last.next = &l.root
+	l.root.prev = last
+	l.len += other.len
+	other.Init()
+}
+
+// Compact removes from list l every element whose value equals that of the
+// element before it, so that each run of equal values is reduced to its
+// first element. It returns the number of elements removed.
+func Compact[T comparable](l *List[T]) int {
+	return l.CompactFunc(func(a, b T) bool { return a == b })
+}
+
+// CompactFunc is like Compact but uses eq to compare adjacent values.
+// The element kept from each run is the first, and eq is called with the
+// value of the last kept element and that of the element after it.
+func (l *List[T]) CompactFunc(eq func(a, b T) bool) int {
+	removed := 0
+	kept := l.Front()
+	if kept == nil {
+		return 0
+	}
+	for e := kept.Next(); e != nil; {
+		next := e.Next()
+		if eq(kept.Value, e.Value) {
+			l.remove(e)
+			removed++
+		} else {
+			kept = e
+		}
+		e = next
+	}
+	return removed
+}
+
+// Unique removes from list l every element whose value equals that of an
+// earlier element, keeping the first occurrence of each value and the
+// order of the survivors. It returns the number of elements removed.
+// Unlike Compact, duplicates need not be adjacent.
+func Unique[T comparable](l *List[T]) int {
+	return DistinctFunc(l, func(v T) T { return v })
+}
+
+// UniqueBy is like Unique but treats two values as duplicates when key
+// returns the same result for both. It is equivalent to DistinctFunc.
+func UniqueBy[T any, K comparable](l *List[T], key func(T) K) int {
+	return DistinctFunc(l, key)
+}
+
+// Count returns the number of elements of list l whose value equals v.
+func Count[T comparable](l *List[T], v T) int {
+	return l.CountFunc(func(x T) bool { return x == v })
+}
+
+// CountFunc returns the number of elements of list l whose value
+// satisfies pred.
+func (l *List[T]) CountFunc(pred func(T) bool) int {
+	n := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			n++
+		}
+	}
+	return n
+}
+
+// Any reports whether the value of at least one element of list l
+// satisfies pred. It stops at the first match and is false for an empty
+// list.
+func (l *List[T]) Any(pred func(T) bool) bool {
+	return l.ContainsFunc(pred)
+}
+
+// Every reports whether the value of every element of list l satisfies
+// pred. It stops at the first mismatch and is true for an empty list.
+func (l *List[T]) Every(pred func(T) bool) bool {
+	return !l.ContainsFunc(func(v T) bool { return !pred(v) })
+}
+
+// FindFunc returns the first element of list l whose value satisfies
+// pred, or nil if there is none.
+func (l *List[T]) FindFunc(pred func(T) bool) *Element[T] {
+	e, _ := l.FindElementAndIndex(pred)
+	return e
+}
+
+// FindLastFunc returns the last element of list l whose value satisfies
+// pred, or nil if there is none. It scans from the back.
+func (l *List[T]) FindLastFunc(pred func(T) bool) *Element[T] {
+	for e := l.Back(); e != nil; e = e.Prev() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Replace sets to new the value of the first element of list l whose value
+// equals old, and reports whether one was found. The element is modified
+// in place rather than relinked.
+func Replace[T comparable](l *List[T], old, new T) bool {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value == old {
+			e.Value = new
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceAll sets to new the value of every element of list l whose value
+// equals old, and returns the number of elements modified.
+func ReplaceAll[T comparable](l *List[T], old, new T) int {
+	n := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value == old {
+			e.Value = new
+			n++
+		}
+	}
+	return n
+}
+
+// Fill sets the value of every element of list l to v. The length and the
+// elements themselves are unchanged.
+func (l *List[T]) Fill(v T) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		e.Value = v
+	}
+}
+
+// Take returns a new list holding copies of the first n values of list l.
+// n is clamped to [0, l.Len()], and l is not modified.
+func (l *List[T]) Take(n int) *List[T] {
+	out := New[T]()
+	for e := l.Front(); e != nil && out.len < n; e = e.Next() {
+		out.insertValue(e.Value, out.root.prev)
+	}
+	return out
+}
+
+// Drop returns a new list holding copies of the values of list l after the
+// first n. n is clamped to [0, l.Len()], and l is not modified.
+func (l *List[T]) Drop(n int) *List[T] {
+	out := New[T]()
+	for e := l.At(max(n, 0)); e != nil; e = e.Next() {
+		out.insertValue(e.Value, out.root.prev)
+	}
+	return out
+}
+
+// TakeWhile returns a new list holding copies of the values of the longest
+// prefix of list l whose values all satisfy pred. l is not modified.
+func (l *List[T]) TakeWhile(pred func(T) bool) *List[T] {
+	out := New[T]()
+	for e := l.Front(); e != nil && pred(e.Value); e = e.Next() {
+		out.insertValue(e.Value, out.root.prev)
+	}
+	return out
+}
+
+// DropWhile returns a new list holding copies of the values of list l that
+// follow the prefix TakeWhile would return. l is not modified.
+func (l *List[T]) DropWhile(pred func(T) bool) *List[T] {
+	out := New[T]()
+	e := l.Front()
+	for e != nil && pred(e.Value) {
+		e = e.Next()
+	}
+	for ; e != nil; e = e.Next() {
+		out.insertValue(e.Value, out.root.prev)
+	}
+	return out
+}
+
+// Chunk splits a copy of list l into consecutive new lists of size values
+// each, in order; the last chunk holds the remainder and may be shorter.
+// An empty list yields no chunks. The list l is not modified. Chunk
+// panics if size <= 0.
+func Chunk[T any](l *List[T], size int) []*List[T] {
+	if size <= 0 {
+		panic("list: Chunk called with size <= 0")
+	}
+	chunks := make([]*List[T], 0, (l.Len()+size-1)/size)
+	for e := l.Front(); e != nil; e = e.Next() {
+		if n := len(chunks); n == 0 || chunks[n-1].len == size {
+			chunks = append(chunks, New[T]())
+		}
+		c := chunks[len(chunks)-1]
+		c.insertValue(e.Value, c.root.prev)
+	}
+	return chunks
+}
+
+// MarshalJSON implements json.Marshaler. It encodes list l as a JSON array
+// of its values, from front to back.
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of
+// list l with the values of a JSON array; null yields an empty list. If
+// decoding fails, l is not modified.
+func (l *List[T]) UnmarshalJSON(b []byte) error {
+	var s []T
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	l.Clear()
+	for _, v := range s {
+		l.insertValue(v, l.root.prev)
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. It encodes the values of list l,
+// from front to back, as a gob-encoded slice.
+func (l *List[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(l.ToSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. It replaces the contents of list l
+// with values encoded by GobEncode. If decoding fails, l is not modified.
+func (l *List[T]) GobDecode(b []byte) error {
+	var s []T
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&s); err != nil {
+		return err
+	}
+	l.Clear()
+	for _, v := range s {
+		l.insertValue(v, l.root.prev)
+	}
+	return nil
+}
+
+// QuickSort sorts list l in ascending order by cmp with a quicksort that
+// picks each pivot as the median of three values and sorts short ranges
+// by insertion. After 2*log2(n) levels of partitioning, the remaining
+// range is sorted with slices.SortFunc, so the running time is
+// O(n log n) even on adversarial inputs. The sort is not stable. Elements
+// are relinked rather than copied, so existing element handles remain
+// valid.
+func (l *List[T]) QuickSort(cmp func(a, b T) int) {
+	l.lazyInit()
+	first := l.Front()
+	last := l.Back()
+	_qsort(l, first, last, l.len, 2*bits.Len(uint(l.len)), qsortCutoff, cmp)
+}
+
+// SortStable sorts list l by cmp with a bottom-up merge sort, keeping
+// elements that compare equal in their original order. It runs in
+// O(n log n) time and relinks the existing elements, so existing element
+// handles remain valid.
+func (l *List[T]) SortStable(cmp func(a, b T) int) {
+	l.mergeSort(cmp)
+}
+
+// IsSorted reports whether list l is sorted in ascending order by cmp,
+// that is, whether cmp(prev, next) <= 0 for every pair of adjacent values.
+func (l *List[T]) IsSorted(cmp func(a, b T) int) bool {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if next := e.Next(); next != nil && cmp(e.Value, next.Value) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort sorts list l in ascending order using cmp.Compare. It is the
+// comparator-free counterpart of QuickSort for ordered types, in the same
+// way that slices.Sort is for slices.SortFunc.
+func Sort[T cmp.Ordered](l *List[T]) {
+	l.QuickSort(cmp.Compare[T])
+}
+
+// QuickSortFunc sorts list l with QuickSort, ordering values by a less
+// function of the kind passed to sort.Slice rather than a three-way
+// comparison.
+func (l *List[T]) QuickSortFunc(less func(a, b T) bool) {
+	l.QuickSort(func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+}
+
+// QuickSortValues sorts list l by cmp, moving values between elements
+// rather than relinking the elements themselves: the values are copied
+// out, sorted with slices.SortFunc (a quicksort variant) and written
+// back in order. This is cheaper than QuickSort when T is small, but an
+// element handle keeps its position and observes a different value
+// afterwards. The sort is not stable.
+func (l *List[T]) QuickSortValues(cmp func(a, b T) int) {
+	s := l.ToSlice()
+	slices.SortFunc(s, cmp)
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		e.Value = s[i]
+		i++
+	}
+}
+
+// SortFuncTracked stably sorts list l by cmp, relinking the elements, and
+// then calls onMove, from front to back, for every element whose index
+// changed. Element handles stay attached to their values, so onMove is
+// only needed for bookkeeping that depends on position. Elements that end
+// up at the index they started from are not reported.
+func (l *List[T]) SortFuncTracked(cmp func(a, b T) int, onMove func(e *Element[T])) {
+	before := make([]*Element[T], 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		before = append(before, e)
+	}
+	l.mergeSort(cmp)
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if before[i] != e {
+			onMove(e)
+		}
+		i++
+	}
+}
+
+// ShellSort sorts list l by cmp with a Shell sort over a slice of its
+// elements, using Ciura's gap sequence, and then relinks the elements in
+// sorted order. Beyond the last of Ciura's gaps, each gap is 2.25 times
+// the previous. The sort is iterative and not stable; Ciura's gaps have no
+// proven bound, but the number of comparisons grows empirically at about
+// O(n^(4/3)).
+func (l *List[T]) ShellSort(cmp func(a, b T) int) {
+	es := make([]*Element[T], 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		es = append(es, e)
+	}
+	gaps := []int{1, 4, 10, 23, 57, 132, 301, 701}
+	for g := gaps[len(gaps)-1]; g < len(es)/2; {
+		g = g * 9 / 4
+		gaps = append(gaps, g)
+	}
+	for k := len(gaps) - 1; k >= 0; k-- {
+		gap := gaps[k]
+		for i := gap; i < len(es); i++ {
+			e := es[i]
+			j := i
+			for ; j >= gap && cmp(es[j-gap].Value, e.Value) > 0; j -= gap {
+				es[j] = es[j-gap]
+			}
+			es[j] = e
+		}
+	}
+	l.relink(es)
+}
+
+// qsortCutoff is the length at or below which QuickSort sorts a range with
+// insertionSortRange instead of partitioning it further.
+const qsortCutoff = 12
+
+// _qsort sorts the n elements from left to right inclusive, switching to
+// insertion sort for ranges of at most cutoff elements. It recurses only
+// into the smaller partition and loops on the larger one, so the recursion
+// depth is at most log2(n). Once limit partitions have been made along one
+// path the remaining range is sorted by sortRange instead, which bounds
+// the running time on inputs that defeat the pivot choice.
+func _qsort[T any](lst *List[T], left, right *Element[T], n, limit, cutoff int, cmp func(a, b T) int) {
+	for n > 1 {
+		if n <= cutoff {
+			insertionSortRange(lst, left, right, cmp)
+			return
+		}
+		if limit == 0 {
+			sortRange(left, right, n, cmp)
+			return
+		}
+		limit--
+		// Move the median of the first, middle and last values to the front,
+		// so that already sorted or reverse sorted input splits evenly. It is
+		// relinked rather than swapped to keep the order of the other values.
+		if m := medianOfThree(left, right, n, cmp); m != left {
+			if m == right {
+				right = right.prev
+			}
+			lst.move(m, left.prev)
+			left = m
+		}
+		// LBoundary and RBoundary are boundaries before left and after right
+		LBoundary := left.prev
+		RBoundary := right.next
+		pivotValue := left.Value
+		nLeft := 0 // number of times left has moved, which ends as the size of the lower partition
+		for {
+			// Right moves first, and finally finds a value within the [left, right] interval that is<left or==left (only when it coincides with left)
+			for right != left && cmp(right.Value, pivotValue) >= 0 {
+				right = right.Prev()
+			}
+			// Then move left and finally find a value within the [left, right] interval that is>left or==left (only when it coincides with left)
+			for left != right && cmp(left.Value, pivotValue) <= 0 {
+				left = left.Next()
+				nLeft++
+			}
+			if left == right {
+				break
+			}
+			isNeighbour := swap(left, right)
+			left, right = right, left
+			if isNeighbour { // When adjacent, there is no need for the next loop. In fact, there can be no break. The next time left and right are equal, there will also be a break
+				break
+			}
+		}
+		// After the loop ends, the position of left is the right boundary of all values less than or equal to pivotValue
+		// Move the pivot, still at the front, to just after left, again keeping the order of the other values
+		finalPivot := LBoundary.next
+		lst.move(finalPivot, left)
+		nRight := n - nLeft - 1
+		if nLeft < nRight {
+			_qsort(lst, LBoundary.next, finalPivot.prev, nLeft, limit, cutoff, cmp)
+			left, right, n = finalPivot.next, RBoundary.prev, nRight
+		} else {
+			_qsort(lst, finalPivot.next, RBoundary.prev, nRight, limit, cutoff, cmp)
+			left, right, n = LBoundary.next, finalPivot.prev, nLeft
+		}
+	}
+}
+
+// insertionSortRange sorts the elements from left to right inclusive with
+// an insertion sort that relinks each element into place.
+func insertionSortRange[T any](lst *List[T], left, right *Element[T], cmp func(a, b T) int) {
+	before, after := left.prev, right.next
+	for e := left.next; e != after; {
+		next := e.next
+		at := e.prev
+		for at != before && cmp(at.Value, e.Value) > 0 {
+			at = at.prev
+		}
+		if at != e.prev {
+			lst.move(e, at)
+		}
+		e = next
+	}
+}
+
+// sortRange sorts the n elements from left to right inclusive by collecting
+// them into a slice, sorting it, and relinking them in sorted order.
+func sortRange[T any](left, right *Element[T], n int, cmp func(a, b T) int) {
+	before, after := left.prev, right.next
+	es := make([]*Element[T], 0, n)
+	for e := left; e != after; e = e.next {
+		es = append(es, e)
+	}
+	slices.SortFunc(es, func(a, b *Element[T]) int { return cmp(a.Value, b.Value) })
+	relinkBetween(before, after, es)
+}
+
+// medianOfThree returns whichever of left, the element midway between
+// left and right, and right holds the median value according to cmp,
+// where n is the number of elements from left to right inclusive.
+func medianOfThree[T any](left, right *Element[T], n int, cmp func(a, b T) int) *Element[T] {
+	if n < 3 {
+		return left
+	}
+	mid := left
+	for i := n / 2; i > 0; i-- {
+		mid = mid.next
+	}
+	a, b, c := left, mid, right
+	if cmp(a.Value, b.Value) > 0 {
+		a, b = b, a
+	}
+	if cmp(b.Value, c.Value) > 0 {
+		b = c
+		if cmp(a.Value, b.Value) > 0 {
+			b = a
+		}
+	}
+	return b
+}
+
+func swap[T any](b, d *Element[T]) (neighbor bool) {
+	if b != d {
+		if b.next == d || b.prev == d { // are neighours
+			if b.next == d { // a <=> b <=> d <=> e
+				b.prev.next = d
+				d.next.prev = b
+				d.prev = b.prev
+				b.next = d.next
+				d.next = b
+				b.prev = d
+			} else { // a <=> d <=> b <=> e
+				d.prev.next = b
+				b.next.prev = d
+				b.prev = d.prev
+				d.next = b.next
+				b.next = d
+				d.prev = b
+			}
+			neighbor = true
+		} else { // a <=> b <=> c <=> d <=> e
+			b.next.prev = d
+			b.prev.next = d
+			d.next.prev = b
+			d.prev.next = b
+			bPrevP := b.prev
+			bNextP := b.next
+			b.prev = d.prev
+			b.next = d.next
+			d.prev = bPrevP
+			d.next = bNextP
+		}
+	}
+	return
+}
+
+// mergeSort stably sorts l by cmp with a bottom-up merge sort that
+// relinks the existing elements.
+func (l *List[T]) mergeSort(cmp func(a, b T) int) {
+	if l.len < 2 {
+		return
+	}
+	// Detach the elements into a chain terminated by a nil next pointer;
+	// prev pointers are restored once the chain is sorted.
+	head := l.root.next
+	l.root.prev.next = nil
+	for k := 1; ; k *= 2 {
+		var first, tail *Element[T]
+		merges := 0
+		for p := head; p != nil; {
+			merges++
+			q, psize := p, 0
+			for ; psize < k && q != nil; psize++ {
+				q = q.next
+			}
+			qsize := k
+			for psize > 0 || (qsize > 0 && q != nil) {
+				var e *Element[T]
+				// Taking from p on ties keeps the sort stable.
+				if psize == 0 || (qsize > 0 && q != nil && cmp(q.Value, p.Value) < 0) {
+					e, q = q, q.next
+					qsize--
+				} else {
+					e, p = p, p.next
+					psize--
+				}
+				if tail == nil {
+					first = e
+				} else {
+					tail.next = e
+				}
+				tail = e
+			}
+			p = q
+		}
+		tail.next = nil
+		head = first
+		if merges <= 1 {
+			break
+		}
+	}
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// relink rebuilds the ring of l so that its elements appear in the order
+// of es, which must hold exactly the elements of l.
+func (l *List[T]) relink(es []*Element[T]) {
+	relinkBetween(&l.root, &l.root, es)
+}
+
+// relinkBetween links the elements of es, in order, between before and
+// after, replacing whatever was linked between them.
+func relinkBetween[T any](before, after *Element[T], es []*Element[T]) {
+	prev := before
+	for _, e := range es {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = after
+	after.prev = prev
 }
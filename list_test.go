@@ -1,11 +1,49 @@
 package list
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
+// checkList verifies that l holds exactly the values in want, in order,
+// walking both forward and backward.
+func checkList[T comparable](t *testing.T, l *List[T], want []T) {
+	t.Helper()
+	if err := l.WellFormed(); err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != len(want) {
+		t.Fatalf("len %d, want %d", l.Len(), len(want))
+	}
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value != want[i] {
+			t.Fatalf("index %d: got %+v, want %+v", i, e.Value, want[i])
+		}
+		i++
+	}
+	for e := l.Back(); e != nil; e = e.Prev() {
+		i--
+		if e.Value != want[i] {
+			t.Fatalf("backward index %d: got %+v, want %+v", i, e.Value, want[i])
+		}
+	}
+}
+
 func TestList(t *testing.T) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -42,3 +80,2257 @@ func TestList(t *testing.T) {
 		}
 	}
 }
+
+func TestTransform(t *testing.T) {
+	lst := New[int]()
+	for i := 1; i <= 6; i++ {
+		lst.PushBack(i)
+	}
+	removed := lst.Transform(func(v int) (int, bool) {
+		return v * 10, v%2 == 0
+	})
+	if removed != 3 {
+		t.Fatalf("removed %d, want 3", removed)
+	}
+	checkList(t, lst, []int{20, 40, 60})
+}
+
+func TestFlatMap(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{2, 0, 3} {
+		lst.PushBack(v)
+	}
+	out := FlatMap(lst, func(n int) []string {
+		var s []string
+		for i := 0; i < n; i++ {
+			s = append(s, string(rune('a'+n)))
+		}
+		return s
+	})
+	checkList(t, out, []string{"c", "c", "d", "d", "d"})
+	checkList(t, lst, []int{2, 0, 3})
+}
+
+func TestNearest(t *testing.T) {
+	dist := func(a, b int) float64 {
+		if a > b {
+			return float64(a - b)
+		}
+		return float64(b - a)
+	}
+	lst := New[int]()
+	if e, ok := lst.Nearest(5, dist); e != nil || ok {
+		t.Fatalf("empty list: got %v, %v", e, ok)
+	}
+	for _, v := range []int{10, 3, 7, 1} {
+		lst.PushBack(v)
+	}
+	e, ok := lst.Nearest(5, dist)
+	if !ok || e.Value != 3 {
+		t.Fatalf("got %v, %v, want 3 (first of the tie)", e.Value, ok)
+	}
+	if e, _ := lst.Nearest(9, dist); e.Value != 10 {
+		t.Fatalf("got %v, want 10", e.Value)
+	}
+}
+
+func TestDivide(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 7; i++ {
+		lst.PushBack(i)
+	}
+	parts := lst.Divide(3)
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3", len(parts))
+	}
+	checkList(t, parts[0], []int{0, 1, 2})
+	checkList(t, parts[1], []int{3, 4})
+	checkList(t, parts[2], []int{5, 6})
+	checkList(t, lst, []int{0, 1, 2, 3, 4, 5, 6})
+
+	parts = New[int]().Divide(2)
+	if len(parts) != 2 || parts[0].Len() != 0 || parts[1].Len() != 0 {
+		t.Fatalf("empty list: got %d parts", len(parts))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Divide(0) did not panic")
+		}
+	}()
+	lst.Divide(0)
+}
+
+func TestElementMove(t *testing.T) {
+	lst := New[int]()
+	e1 := lst.PushBack(1)
+	e2 := lst.PushBack(2)
+	e3 := lst.PushBack(3)
+
+	e3.MoveToFront()
+	checkList(t, lst, []int{3, 1, 2})
+	e3.MoveToBack()
+	checkList(t, lst, []int{1, 2, 3})
+	e1.MoveAfter(e3)
+	checkList(t, lst, []int{2, 3, 1})
+	e1.MoveBefore(e2)
+	checkList(t, lst, []int{1, 2, 3})
+
+	lst.Remove(e2)
+	e2.MoveToFront()
+	e2.MoveAfter(e3)
+	checkList(t, lst, []int{1, 3})
+}
+
+func TestElementRemove(t *testing.T) {
+	lst := New[int]()
+	lst.PushBack(1)
+	e := lst.PushBack(2)
+	lst.PushBack(3)
+	if v := e.Remove(); v != 2 {
+		t.Fatalf("got %d, want 2", v)
+	}
+	checkList(t, lst, []int{1, 3})
+	if v := e.Remove(); v != 2 {
+		t.Fatalf("detached: got %d, want 2", v)
+	}
+	checkList(t, lst, []int{1, 3})
+}
+
+func TestRunningExtremaFunc(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{5, 3, 8, 4, 1} {
+		lst.PushBack(v)
+	}
+	var lows, highs []int
+	for lo, hi := range lst.RunningExtremaFunc(cmp.Compare[int]) {
+		lows = append(lows, lo)
+		highs = append(highs, hi)
+	}
+	if !slices.Equal(lows, []int{5, 3, 3, 3, 1}) || !slices.Equal(highs, []int{5, 5, 8, 8, 8}) {
+		t.Fatalf("got lows %v, highs %v", lows, highs)
+	}
+	for range New[int]().RunningExtremaFunc(cmp.Compare[int]) {
+		t.Fatal("empty list yielded a value")
+	}
+}
+
+func TestSorted(t *testing.T) {
+	type rec struct{ key, seq int }
+	lst := New[rec]()
+	for i, k := range []int{3, 1, 2, 1, 3, 2, 1} {
+		lst.PushBack(rec{k, i})
+	}
+	sorted := lst.Sorted(func(a, b rec) int { return cmp.Compare(a.key, b.key) })
+	checkList(t, sorted, []rec{{1, 1}, {1, 3}, {1, 6}, {2, 2}, {2, 5}, {3, 0}, {3, 4}})
+	checkList(t, lst, []rec{{3, 0}, {1, 1}, {2, 2}, {1, 3}, {3, 4}, {2, 5}, {1, 6}})
+
+	if s := New[int]().Sorted(cmp.Compare[int]); s.Len() != 0 {
+		t.Fatalf("empty list: got len %d", s.Len())
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 0; n < 100; n++ {
+		ints := New[int]()
+		for i := 0; i < n; i++ {
+			ints.PushBack(r.Intn(10))
+		}
+		want := ints.ToSlice()
+		slices.Sort(want)
+		checkList(t, ints.Sorted(cmp.Compare[int]), want)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 1; n < 50; n++ {
+		lst := New[int]()
+		vals := make([]int, n)
+		for i := range vals {
+			vals[i] = r.Intn(20)
+			lst.PushBack(vals[i])
+		}
+		sorted := slices.Sorted(slices.Values(vals))
+		for k := 0; k < n; k++ {
+			if v, ok := lst.Select(k, cmp.Compare[int]); !ok || v != sorted[k] {
+				t.Fatalf("n=%d k=%d: got %d, %v, want %d", n, k, v, ok, sorted[k])
+			}
+		}
+		checkList(t, lst, vals)
+	}
+	lst := New[int]()
+	lst.PushBack(1)
+	for _, k := range []int{-1, 1} {
+		if v, ok := lst.Select(k, cmp.Compare[int]); ok || v != 0 {
+			t.Fatalf("k=%d: got %d, %v, want 0, false", k, v, ok)
+		}
+	}
+}
+
+func TestRotateValues(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 5; i++ {
+		lst.PushBack(i)
+	}
+	front := lst.Front()
+	lst.RotateValues(2)
+	checkList(t, lst, []int{3, 4, 0, 1, 2})
+	if lst.Front() != front || front.Value != 3 {
+		t.Fatalf("front element moved or holds %d", front.Value)
+	}
+	lst.RotateValues(-7)
+	checkList(t, lst, []int{0, 1, 2, 3, 4})
+	lst.RotateValues(5)
+	checkList(t, lst, []int{0, 1, 2, 3, 4})
+	New[int]().RotateValues(3)
+}
+
+// benchmarkSort times sort on lists of random ints of several sizes,
+// restoring the unsorted values before each iteration.
+func benchmarkSort(b *testing.B, sort func(l *List[int])) {
+	for _, n := range []int{100, 10000, 1000000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			vals := make([]int, n)
+			lst := New[int]()
+			for i := range vals {
+				vals[i] = r.Int()
+				lst.PushBack(vals[i])
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				j := 0
+				for e := lst.Front(); e != nil; e = e.Next() {
+					e.Value = vals[j]
+					j++
+				}
+				b.StartTimer()
+				sort(lst)
+			}
+		})
+	}
+}
+
+func BenchmarkQuickSort(b *testing.B) {
+	benchmarkSort(b, func(l *List[int]) { l.QuickSort(cmp.Compare[int]) })
+}
+
+func BenchmarkMergeSort(b *testing.B) {
+	benchmarkSort(b, func(l *List[int]) { l.mergeSort(cmp.Compare[int]) })
+}
+
+func BenchmarkToSliceSortRebuild(b *testing.B) {
+	benchmarkSort(b, func(l *List[int]) {
+		s := l.ToSlice()
+		slices.SortFunc(s, cmp.Compare[int])
+		l.Init()
+		for _, v := range s {
+			l.PushBack(v)
+		}
+	})
+}
+
+func TestGroupConsecutive(t *testing.T) {
+	lst := New[string]()
+	for _, v := range []string{"a1", "a2", "b1", "a3", "a4", "c1"} {
+		lst.PushBack(v)
+	}
+	groups := GroupConsecutive(lst, func(s string) byte { return s[0] })
+	want := [][]string{{"a1", "a2"}, {"b1"}, {"a3", "a4"}, {"c1"}}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, g := range groups {
+		checkList(t, g, want[i])
+	}
+	if groups := GroupConsecutive(New[string](), func(s string) byte { return s[0] }); len(groups) != 0 {
+		t.Fatalf("empty list: got %d groups", len(groups))
+	}
+}
+
+func TestFindElementAndIndex(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{1, 4, 6, 8} {
+		lst.PushBack(v)
+	}
+	calls := 0
+	e, i := lst.FindElementAndIndex(func(v int) bool {
+		calls++
+		return v%2 == 0
+	})
+	if e == nil || e.Value != 4 || i != 1 || calls != 2 {
+		t.Fatalf("got %v at %d after %d calls, want 4 at 1 after 2 calls", e, i, calls)
+	}
+	if e, i := lst.FindElementAndIndex(func(v int) bool { return v > 10 }); e != nil || i != -1 {
+		t.Fatalf("got %v at %d, want nil at -1", e, i)
+	}
+}
+
+func TestSortByKeyCached(t *testing.T) {
+	lst := New[string]()
+	for _, v := range []string{"ccc", "a", "bb", "d", "ee", "f"} {
+		lst.PushBack(v)
+	}
+	front := lst.Front()
+	calls := 0
+	SortByKeyCached(lst, func(s string) int {
+		calls++
+		return len(s)
+	})
+	checkList(t, lst, []string{"a", "d", "f", "bb", "ee", "ccc"})
+	if calls != 6 {
+		t.Fatalf("key called %d times, want 6", calls)
+	}
+	if lst.Back() != front {
+		t.Fatal("element handle not preserved")
+	}
+}
+
+func benchmarkSortByKey(b *testing.B, sort func(l *List[string], key func(string) time.Time)) {
+	key := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return t
+	}
+	r := rand.New(rand.NewSource(1))
+	vals := make([]string, 10000)
+	lst := New[string]()
+	for i := range vals {
+		vals[i] = time.Unix(r.Int63n(1<<31), 0).UTC().Format(time.RFC3339)
+		lst.PushBack(vals[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		j := 0
+		for e := lst.Front(); e != nil; e = e.Next() {
+			e.Value = vals[j]
+			j++
+		}
+		b.StartTimer()
+		sort(lst, key)
+	}
+}
+
+func BenchmarkSortByKeyCached(b *testing.B) {
+	benchmarkSortByKey(b, func(l *List[string], key func(string) time.Time) {
+		SortByKeyCached(l, func(s string) int64 { return key(s).UnixNano() })
+	})
+}
+
+func BenchmarkSortByKeyRepeated(b *testing.B) {
+	benchmarkSortByKey(b, func(l *List[string], key func(string) time.Time) {
+		l.mergeSort(func(a, b string) int { return key(a).Compare(key(b)) })
+	})
+}
+
+func TestDrain(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 5; i++ {
+		lst.PushBack(i)
+	}
+	var got []int
+	for v := range lst.Drain() {
+		got = append(got, v)
+		if v == 1 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{0, 1}) {
+		t.Fatalf("got %v, want [0 1]", got)
+	}
+	checkList(t, lst, []int{2, 3, 4})
+	got = slices.Collect(lst.Drain())
+	if !slices.Equal(got, []int{2, 3, 4}) {
+		t.Fatalf("got %v, want [2 3 4]", got)
+	}
+	checkList(t, lst, []int{})
+	lst.PushBack(7)
+	checkList(t, lst, []int{7})
+}
+
+func TestGroupMatchingToFront(t *testing.T) {
+	lst := New[int]()
+	for i := 1; i <= 8; i++ {
+		lst.PushBack(i)
+	}
+	back := lst.Back()
+	lst.GroupMatchingToFront(func(v int) bool { return v%3 != 1 })
+	checkList(t, lst, []int{2, 3, 5, 6, 8, 1, 4, 7})
+	if back.Value != 8 || back.Next() != lst.Back().Prev().Prev() {
+		t.Fatal("element handle not preserved")
+	}
+	lst.GroupMatchingToFront(func(int) bool { return false })
+	checkList(t, lst, []int{2, 3, 5, 6, 8, 1, 4, 7})
+	lst.GroupMatchingToFront(func(int) bool { return true })
+	checkList(t, lst, []int{2, 3, 5, 6, 8, 1, 4, 7})
+}
+
+func TestQuickSortValues(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 0; n < 200; n += 7 {
+		byNodes, byValues := New[int](), New[int]()
+		for i := 0; i < n; i++ {
+			v := r.Intn(50)
+			byNodes.PushBack(v)
+			byValues.PushBack(v)
+		}
+		front := byValues.Front()
+		byNodes.QuickSort(cmp.Compare[int])
+		byValues.QuickSortValues(cmp.Compare[int])
+		checkList(t, byValues, byNodes.ToSlice())
+		if byValues.Front() != front {
+			t.Fatal("QuickSortValues relinked the front element")
+		}
+	}
+}
+
+func TestWellFormed(t *testing.T) {
+	var zero List[int]
+	if err := zero.WellFormed(); err != nil {
+		t.Fatalf("zero list: %v", err)
+	}
+	lst := New[int]()
+	for i := 0; i < 4; i++ {
+		lst.PushBack(i)
+	}
+	if err := lst.WellFormed(); err != nil {
+		t.Fatal(err)
+	}
+
+	lst.len++
+	if err := lst.WellFormed(); err == nil {
+		t.Fatal("length mismatch not detected")
+	}
+	lst.len--
+
+	e := lst.Front().Next()
+	e.list = nil
+	if err := lst.WellFormed(); err == nil {
+		t.Fatal("foreign element not detected")
+	}
+	e.list = lst
+
+	saved := e.prev
+	e.prev = e
+	if err := lst.WellFormed(); err == nil {
+		t.Fatal("broken prev link not detected")
+	}
+	e.prev = saved
+
+	saved = e.next
+	e.next = nil
+	if err := lst.WellFormed(); err == nil {
+		t.Fatal("nil next link not detected")
+	}
+	e.next = saved
+	if err := lst.WellFormed(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSortFuncTracked(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{1, 4, 3, 2, 5} {
+		lst.PushBack(v)
+	}
+	var moved []int
+	lst.SortFuncTracked(cmp.Compare[int], func(e *Element[int]) {
+		moved = append(moved, e.Value)
+	})
+	checkList(t, lst, []int{1, 2, 3, 4, 5})
+	if !slices.Equal(moved, []int{2, 4}) {
+		t.Fatalf("moved %v, want [2 4]", moved)
+	}
+}
+
+func TestElementTake(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 5; i++ {
+		lst.PushBack(i)
+	}
+	e := lst.Front().Next()
+	if got := slices.Collect(e.Take(2)); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if got := slices.Collect(e.Take(10)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("got %v, want [1 2 3 4]", got)
+	}
+	seq := e.Take(3)
+	for i := 0; i < 2; i++ {
+		if got := slices.Collect(seq); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Fatalf("pass %d: got %v, want [1 2 3]", i, got)
+		}
+	}
+	if got := slices.Collect(e.Take(0)); len(got) != 0 {
+		t.Fatalf("got %v, want []", got)
+	}
+	lst.Remove(e)
+	if got := slices.Collect(e.Take(2)); len(got) != 0 {
+		t.Fatalf("detached: got %v, want []", got)
+	}
+}
+
+func TestShellSort(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, n := range []int{0, 1, 2, 10, 100, 1000, 5000} {
+		lst := New[int]()
+		want := make([]int, n)
+		for i := range want {
+			want[i] = r.Intn(n + 1)
+			lst.PushBack(want[i])
+		}
+		slices.Sort(want)
+		lst.ShellSort(cmp.Compare[int])
+		checkList(t, lst, want)
+	}
+}
+
+func BenchmarkSort1000(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func(l *List[int])
+	}{
+		{"QuickSort", func(l *List[int]) { l.QuickSort(cmp.Compare[int]) }},
+		{"MergeSort", func(l *List[int]) { l.mergeSort(cmp.Compare[int]) }},
+		{"ShellSort", func(l *List[int]) { l.ShellSort(cmp.Compare[int]) }},
+	}
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+	for _, s := range sorts {
+		b.Run(s.name, func(b *testing.B) {
+			lst := New[int]()
+			for _, v := range vals {
+				lst.PushBack(v)
+			}
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				j := 0
+				for e := lst.Front(); e != nil; e = e.Next() {
+					e.Value = vals[j]
+					j++
+				}
+				b.StartTimer()
+				s.sort(lst)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	type rec struct {
+		id   int
+		name string
+	}
+	lst := New[rec]()
+	for _, r := range []rec{{1, "a"}, {2, "b"}, {1, "c"}} {
+		lst.PushBack(r)
+	}
+	id := func(r rec) int { return r.id }
+	m := ToMap(lst, id)
+	if len(m) != 2 || m[1].name != "c" || m[2].name != "b" {
+		t.Fatalf("got %v", m)
+	}
+	if _, err := ToMapE(lst, id); err == nil {
+		t.Fatal("duplicate key not reported")
+	}
+	lst.Remove(lst.Back())
+	if m, err := ToMapE(lst, id); err != nil || len(m) != 2 || m[1].name != "a" {
+		t.Fatalf("got %v, %v", m, err)
+	}
+	if m := ToMap(New[rec](), id); m == nil || len(m) != 0 {
+		t.Fatalf("empty list: got %v", m)
+	}
+}
+
+func TestSendToDrainChannel(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 100; i++ {
+		lst.PushBack(i)
+	}
+	ch := make(chan int)
+	go func() {
+		lst.SendTo(ch)
+		close(ch)
+	}()
+	out := DrainChannel(ch)
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i
+	}
+	checkList(t, out, want)
+	checkList(t, lst, want)
+}
+
+func TestMergeSortedInto(t *testing.T) {
+	a, b := New[int](), New[int]()
+	for _, v := range []int{1, 3, 5, 7} {
+		a.PushBack(v)
+	}
+	for _, v := range []int{0, 3, 4, 9, 10} {
+		b.PushBack(v)
+	}
+	e := b.Front()
+	a.MergeSortedInto(b, cmp.Compare[int])
+	checkList(t, a, []int{0, 1, 3, 3, 4, 5, 7, 9, 10})
+	checkList(t, b, []int{})
+	if a.Front() != e {
+		t.Fatal("element handle not preserved")
+	}
+
+	a.MergeSortedInto(a, cmp.Compare[int])
+	checkList(t, a, []int{0, 1, 3, 3, 4, 5, 7, 9, 10})
+
+	var zero List[int]
+	zero.MergeSortedInto(a, cmp.Compare[int])
+	checkList(t, &zero, []int{0, 1, 3, 3, 4, 5, 7, 9, 10})
+	checkList(t, a, []int{})
+}
+
+func TestInversions(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 0; n < 40; n++ {
+		lst := New[int]()
+		vals := make([]int, n)
+		for i := range vals {
+			vals[i] = r.Intn(10)
+			lst.PushBack(vals[i])
+		}
+		want := 0
+		for i := range vals {
+			for j := i + 1; j < n; j++ {
+				if vals[i] > vals[j] {
+					want++
+				}
+			}
+		}
+		if got := lst.Inversions(cmp.Compare[int]); got != want {
+			t.Fatalf("%v: got %d inversions, want %d", vals, got, want)
+		}
+		checkList(t, lst, vals)
+	}
+}
+
+func TestMedianTracker(t *testing.T) {
+	m := NewMedianTracker(cmp.Compare[int])
+	if v, ok := m.Median(); ok || v != 0 {
+		t.Fatalf("empty tracker: got %d, %v", v, ok)
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var seen []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(50)
+		m.Add(v)
+		seen = append(seen, v)
+		sorted := slices.Sorted(slices.Values(seen))
+		want := sorted[(len(sorted)-1)/2]
+		if got, ok := m.Median(); !ok || got != want {
+			t.Fatalf("after %v: got %d, want %d", seen, got, want)
+		}
+		if m.Len() != len(seen) {
+			t.Fatalf("len %d, want %d", m.Len(), len(seen))
+		}
+	}
+	if err := m.low.WellFormed(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.high.WellFormed(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDistinctFunc(t *testing.T) {
+	type rec struct {
+		id  int
+		tag string
+	}
+	lst := New[rec]()
+	for _, r := range []rec{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}} {
+		lst.PushBack(r)
+	}
+	id := func(r rec) int { return r.id }
+	if n := DistinctFunc(lst, id); n != 2 {
+		t.Fatalf("removed %d, want 2", n)
+	}
+	checkList(t, lst, []rec{{1, "a"}, {2, "b"}, {3, "d"}})
+	if n := DistinctFunc(New[rec](), id); n != 0 {
+		t.Fatalf("empty list: removed %d", n)
+	}
+}
+
+func TestReverseInvolution(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 0; n < 50; n++ {
+		lst := New[int]()
+		for i := 0; i < n; i++ {
+			lst.PushBack(r.Intn(100))
+		}
+		orig := lst.ToSlice()
+		front, back := lst.Front(), lst.Back()
+
+		lst.Reverse()
+		want := slices.Clone(orig)
+		slices.Reverse(want)
+		checkList(t, lst, want)
+		if lst.Front() != back || lst.Back() != front {
+			t.Fatalf("n=%d: Front and Back not swapped", n)
+		}
+
+		lst.Reverse()
+		checkList(t, lst, orig)
+		if lst.Front() != front || lst.Back() != back {
+			t.Fatalf("n=%d: Front and Back not restored", n)
+		}
+	}
+}
+
+func TestRemoveValue(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{1, 2, 3, 2, 2, 4} {
+		lst.PushBack(v)
+	}
+	if !RemoveValue(lst, 2) {
+		t.Fatal("value 2 not found")
+	}
+	checkList(t, lst, []int{1, 3, 2, 2, 4})
+	if RemoveValue(lst, 5) {
+		t.Fatal("absent value reported as found")
+	}
+	if n := RemoveAllValue(lst, 2); n != 2 {
+		t.Fatalf("removed %d, want 2", n)
+	}
+	checkList(t, lst, []int{1, 3, 4})
+	if n := RemoveAllValue(lst, 2); n != 0 {
+		t.Fatalf("removed %d, want 0", n)
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	lst := New[int]()
+	if e, n := lst.LongestRun(cmp.Compare[int]); e != nil || n != 0 {
+		t.Fatalf("empty list: got %v, %d", e, n)
+	}
+	lst.PushBack(5)
+	if e, n := lst.LongestRun(cmp.Compare[int]); e != lst.Front() || n != 1 {
+		t.Fatalf("single element: got %v, %d", e, n)
+	}
+	for _, v := range []int{1, 2, 2, 0, 3, 4, 5, 6, 1} {
+		lst.PushBack(v)
+	}
+	e, n := lst.LongestRun(cmp.Compare[int])
+	if n != 5 || e.Value != 0 {
+		t.Fatalf("got run of %d starting at %v, want 5 starting at 0", n, e.Value)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	a, b := New[int](), New[string]()
+	a.PushBack(1)
+	a.PushBack(2)
+	b.PushBack("x")
+	b.PushBack("y")
+	b.PushBack("z")
+	checkList(t, Product(a, b), []Pair[int, string]{
+		{1, "x"}, {1, "y"}, {1, "z"}, {2, "x"}, {2, "y"}, {2, "z"},
+	})
+	joined := ProductWith(a, b, func(n int, s string) string { return fmt.Sprint(n, s) })
+	checkList(t, joined, []string{"1x", "1y", "1z", "2x", "2y", "2z"})
+	checkList(t, Product(a, New[string]()), []Pair[int, string]{})
+	checkList(t, Product(New[int](), b), []Pair[int, string]{})
+}
+
+func TestConcatSeq(t *testing.T) {
+	a, b := New[int](), New[int]()
+	a.PushBack(1)
+	a.PushBack(2)
+	b.PushBack(3)
+	got := slices.Collect(ConcatSeq(a, nil, New[int](), b))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+	got = got[:0]
+	for v := range ConcatSeq(a, b) {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	for range ConcatSeq[int]() {
+		t.Fatal("no lists yielded a value")
+	}
+}
+
+func TestDiffIndex(t *testing.T) {
+	build := func(vs ...int) *List[int] {
+		l := New[int]()
+		for _, v := range vs {
+			l.PushBack(v)
+		}
+		return l
+	}
+	eq := func(x, y int) bool { return x == y }
+	tests := []struct {
+		a, b *List[int]
+		want int
+	}{
+		{build(1, 2, 3), build(1, 2, 3), -1},
+		{build(), build(), -1},
+		{build(1, 2, 3), build(1, 5, 3), 1},
+		{build(1, 2), build(1, 2, 3), 2},
+		{build(1, 2, 3), build(1), 1},
+		{build(), build(4), 0},
+	}
+	for i, tt := range tests {
+		if got := DiffIndex(tt.a, tt.b, eq); got != tt.want {
+			t.Errorf("case %d: got %d, want %d", i, got, tt.want)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	lst := New[int]()
+	front := lst.PushBack(1)
+	lst.PushBack(2)
+	lst.Resize(4, 9)
+	checkList(t, lst, []int{1, 2, 9, 9})
+	lst.Resize(1, 9)
+	checkList(t, lst, []int{1})
+	if lst.Front() != front {
+		t.Fatal("surviving element handle not preserved")
+	}
+	lst.Resize(-3, 9)
+	checkList(t, lst, []int{})
+	var zero List[int]
+	zero.Resize(2, 7)
+	checkList(t, &zero, []int{7, 7})
+}
+
+func TestReposition(t *testing.T) {
+	lst := New[int]()
+	var es []*Element[int]
+	for _, v := range []int{1, 3, 5, 7, 9} {
+		es = append(es, lst.PushBack(v))
+	}
+	es[1].Value = 8
+	lst.Reposition(es[1], cmp.Compare[int])
+	checkList(t, lst, []int{1, 5, 7, 8, 9})
+	es[4].Value = 0
+	lst.Reposition(es[4], cmp.Compare[int])
+	checkList(t, lst, []int{0, 1, 5, 7, 8})
+	es[2].Value = 6
+	lst.Reposition(es[2], cmp.Compare[int])
+	checkList(t, lst, []int{0, 1, 6, 7, 8})
+	es[3].Value = 10
+	lst.Reposition(es[3], cmp.Compare[int])
+	checkList(t, lst, []int{0, 1, 6, 8, 10})
+
+	other := New[int]()
+	e := other.PushBack(-1)
+	lst.Reposition(e, cmp.Compare[int])
+	checkList(t, lst, []int{0, 1, 6, 8, 10})
+}
+
+func TestPeekIter(t *testing.T) {
+	lst := New[string]()
+	for _, v := range []string{"a", "b"} {
+		lst.PushBack(v)
+	}
+	it := lst.PeekIter()
+	steps := []struct {
+		peek bool
+		want string
+		ok   bool
+	}{
+		{true, "a", true},
+		{true, "a", true},
+		{false, "a", true},
+		{true, "b", true},
+		{false, "b", true},
+		{true, "", false},
+		{false, "", false},
+	}
+	for i, s := range steps {
+		var v string
+		var ok bool
+		if s.peek {
+			v, ok = it.Peek()
+		} else {
+			v, ok = it.Next()
+		}
+		if v != s.want || ok != s.ok {
+			t.Fatalf("step %d: got %q, %v, want %q, %v", i, v, ok, s.want, s.ok)
+		}
+	}
+	if _, ok := New[string]().PeekIter().Peek(); ok {
+		t.Fatal("empty list: Peek reported a value")
+	}
+}
+
+func TestMergeKUnique(t *testing.T) {
+	type rec struct{ key, src int }
+	build := func(src int, keys ...int) *List[rec] {
+		l := New[rec]()
+		for _, k := range keys {
+			l.PushBack(rec{k, src})
+		}
+		return l
+	}
+	a := build(0, 1, 3, 3, 7)
+	b := build(1, 0, 3, 4, 7, 9)
+	c := build(2, 2, 9)
+	byKey := func(x, y rec) int { return cmp.Compare(x.key, y.key) }
+	out := MergeKUnique(byKey, a, nil, b, New[rec](), c)
+	checkList(t, out, []rec{{0, 1}, {1, 0}, {2, 2}, {3, 0}, {4, 1}, {7, 0}, {9, 1}})
+	checkList(t, a, []rec{{1, 0}, {3, 0}, {3, 0}, {7, 0}})
+	checkList(t, MergeKUnique(byKey), []rec{})
+}
+
+func TestGetE(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 5; i++ {
+		lst.PushBack(i * 10)
+	}
+	for i := 0; i < 5; i++ {
+		if e, err := lst.GetE(i); err != nil || e.Value != i*10 {
+			t.Fatalf("index %d: got %v, %v", i, e, err)
+		}
+	}
+	for _, i := range []int{-1, 5, 12} {
+		e, err := lst.GetE(i)
+		var ie *IndexError
+		if e != nil || !errors.As(err, &ie) || ie.Index != i || ie.Len != 5 {
+			t.Fatalf("index %d: got %v, %v", i, e, err)
+		}
+	}
+	_, err := lst.GetE(12)
+	if want := "list: index 12 out of range for list of length 5"; err.Error() != want {
+		t.Fatalf("got %q, want %q", err, want)
+	}
+}
+
+func TestClearWithStats(t *testing.T) {
+	lst := New[*int]()
+	var es []*Element[*int]
+	for i := 0; i < 3; i++ {
+		es = append(es, lst.PushBack(new(int)))
+	}
+	if n := lst.ClearWithStats(); n != 3 {
+		t.Fatalf("got %d, want 3", n)
+	}
+	checkList(t, lst, []*int{})
+	for i, e := range es {
+		if e.Value != nil || e.Next() != nil || e.Prev() != nil {
+			t.Fatalf("element %d not cleared", i)
+		}
+	}
+	if n := lst.ClearWithStats(); n != 0 {
+		t.Fatalf("got %d, want 0", n)
+	}
+	var zero List[int]
+	if n := zero.ClearWithStats(); n != 0 {
+		t.Fatalf("zero list: got %d, want 0", n)
+	}
+}
+
+func TestPartitionAround(t *testing.T) {
+	lst := New[int]()
+	for _, v := range []int{5, 1, 7, 5, 3, 9, 2, 5} {
+		lst.PushBack(v)
+	}
+	e := lst.PartitionAround(5, cmp.Compare[int])
+	checkList(t, lst, []int{1, 3, 2, 5, 5, 5, 7, 9})
+	if e != lst.At(3) {
+		t.Fatalf("got %v, want the element at index 3", e)
+	}
+	for p := lst.Front(); p != e; p = p.Next() {
+		if p.Value >= 5 {
+			t.Fatalf("%d before the boundary", p.Value)
+		}
+	}
+	for p := e; p != nil; p = p.Next() {
+		if p.Value < 5 {
+			t.Fatalf("%d after the boundary", p.Value)
+		}
+	}
+	if e := lst.PartitionAround(10, cmp.Compare[int]); e != nil {
+		t.Fatalf("got %v, want nil", e.Value)
+	}
+	if e := lst.PartitionAround(0, cmp.Compare[int]); e != lst.Front() {
+		t.Fatalf("got %v, want the front", e.Value)
+	}
+	if e := New[int]().PartitionAround(0, cmp.Compare[int]); e != nil {
+		t.Fatal("empty list: got an element")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	sc := bufio.NewScanner(strings.NewReader("a\nb\nc\n"))
+	lst := Generate(func() (string, bool) {
+		if !sc.Scan() {
+			return "", false
+		}
+		return sc.Text(), true
+	})
+	checkList(t, lst, []string{"a", "b", "c"})
+	empty := Generate(func() (int, bool) { return 1, false })
+	checkList(t, empty, []int{})
+}
+
+func TestRemoveDuringIteration(t *testing.T) {
+	build := func() (*List[int], map[int]*Element[int]) {
+		l := New[int]()
+		es := make(map[int]*Element[int])
+		for i := 0; i < 6; i++ {
+			es[i] = l.PushBack(i)
+		}
+		return l, es
+	}
+	iterators := []struct {
+		name string
+		seq  func(l *List[int]) iter.Seq[int]
+	}{
+		{"Values", func(l *List[int]) iter.Seq[int] { return l.Values() }},
+		{"ConcatSeq", func(l *List[int]) iter.Seq[int] { return ConcatSeq(l) }},
+		{"Element.Take", func(l *List[int]) iter.Seq[int] { return l.Front().Take(l.Len()) }},
+	}
+	for _, it := range iterators {
+		// Removing the element just yielded visits every element once.
+		lst, es := build()
+		var got []int
+		for v := range it.seq(lst) {
+			got = append(got, v)
+			if v%2 == 0 {
+				lst.Remove(es[v])
+			}
+		}
+		if !slices.Equal(got, []int{0, 1, 2, 3, 4, 5}) {
+			t.Fatalf("%s: visited %v", it.name, got)
+		}
+		checkList(t, lst, []int{1, 3, 5})
+
+		// Removing an element two or more positions ahead skips it. This
+		// is not part of the supported contract; it pins down current
+		// behavior only.
+		lst, es = build()
+		got = got[:0]
+		for v := range it.seq(lst) {
+			got = append(got, v)
+			if v == 1 {
+				lst.Remove(es[3])
+			}
+		}
+		if !slices.Equal(got, []int{0, 1, 2, 4, 5}) {
+			t.Fatalf("%s: visited %v", it.name, got)
+		}
+		checkList(t, lst, []int{0, 1, 2, 4, 5})
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	lst := New[int]()
+	for i := 0; i < 4; i++ {
+		lst.PushBack(i)
+	}
+	s := lst.ToSlice()
+	if !slices.Equal(s, []int{0, 1, 2, 3}) || cap(s) != 4 {
+		t.Fatalf("got %v with cap %d", s, cap(s))
+	}
+	lst.Front().Value = 9
+	lst.PushBack(4)
+	if !slices.Equal(s, []int{0, 1, 2, 3}) {
+		t.Fatalf("slice changed with the list: %v", s)
+	}
+	var zero List[int]
+	if s := zero.ToSlice(); s == nil || len(s) != 0 {
+		t.Fatalf("zero list: got %#v", s)
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	for _, s := range [][]int{nil, {}, {1}, {3, 1, 2}} {
+		lst := FromSlice(s)
+		if lst == nil {
+			t.Fatalf("%v: got nil list", s)
+		}
+		checkList(t, lst, s)
+		if got := lst.ToSlice(); !slices.Equal(got, s) {
+			t.Fatalf("round trip: got %v, want %v", got, s)
+		}
+	}
+	lst := FromSlice([]int{})
+	lst.PushBack(1)
+	checkList(t, lst, []int{1})
+}
+
+func TestAll(t *testing.T) {
+	lst := FromSlice([]string{"a", "b", "c", "d"})
+	var got []string
+	for i, v := range lst.All() {
+		if want := lst.At(i).Value; v != want {
+			t.Fatalf("index %d: got %q, want %q", i, v, want)
+		}
+		got = append(got, v)
+		if i == 1 {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+
+	e := lst.Front()
+	n := 0
+	for i := range lst.All() {
+		if i != n {
+			t.Fatalf("got index %d, want %d", i, n)
+		}
+		n++
+		next := e.Next()
+		lst.Remove(e)
+		e = next
+	}
+	if n != 4 {
+		t.Fatalf("visited %d elements while removing, want 4", n)
+	}
+	checkList(t, lst, []string{})
+}
+
+func TestBackward(t *testing.T) {
+	lst := FromSlice([]int{1, 2, 3, 4})
+	var got []int
+	want := 3
+	for i, v := range lst.Backward() {
+		if i != want {
+			t.Fatalf("got index %d, want %d", i, want)
+		}
+		want--
+		got = append(got, v)
+	}
+	rev := lst.ToSlice()
+	slices.Reverse(rev)
+	if !slices.Equal(got, rev) {
+		t.Fatalf("got %v, want %v", got, rev)
+	}
+	got = got[:0]
+	for _, v := range lst.Backward() {
+		if got = append(got, v); v == 3 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{4, 3}) {
+		t.Fatalf("got %v, want [4 3]", got)
+	}
+}
+
+func TestValues(t *testing.T) {
+	lst := FromSlice([]int{5, 6, 7})
+	if got := slices.Collect(lst.Values()); !slices.Equal(got, lst.ToSlice()) {
+		t.Fatalf("got %v, want %v", got, lst.ToSlice())
+	}
+	var got []int
+	for v := range lst.Values() {
+		if got = append(got, v); v == 6 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{5, 6}) {
+		t.Fatalf("got %v, want [5 6]", got)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	checkList(t, Collect(slices.Values([]int{3, 1, 2})), []int{3, 1, 2})
+	src := FromSlice([]string{"x", "y"})
+	dst := Collect(src.Values())
+	checkList(t, dst, []string{"x", "y"})
+	dst.PushBack("z")
+	checkList(t, src, []string{"x", "y"})
+	checkList(t, Collect(slices.Values([]int(nil))), []int{})
+}
+
+func TestApply(t *testing.T) {
+	lst := New[int]()
+	var es []*Element[int]
+	for i := 0; i < 4; i++ {
+		es = append(es, lst.PushBack(i))
+	}
+	lst.Apply(func(v int) int { return v + 1 })
+	checkList(t, lst, []int{1, 2, 3, 4})
+	for i, e := range es {
+		if e.Value != i+1 {
+			t.Fatalf("element %d holds %d, want %d", i, e.Value, i+1)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	lst := FromSlice([]int{1, 3, 2})
+	out := Map(lst, func(n int) string { return strings.Repeat("*", n) })
+	checkList(t, out, []string{"*", "***", "**"})
+	checkList(t, lst, []int{1, 3, 2})
+	checkList(t, Map(New[int](), strconv.Itoa), []string{})
+}
+
+func TestRemoveFunc(t *testing.T) {
+	lst := FromSlice([]int{2, 1, 4, 3, 6, 5, 8})
+	even := func(v int) bool { return v%2 == 0 }
+	if n := lst.RemoveFunc(even); n != 4 {
+		t.Fatalf("removed %d, want 4", n)
+	}
+	checkList(t, lst, []int{1, 3, 5})
+	if n := lst.RemoveFunc(even); n != 0 {
+		t.Fatalf("removed %d, want 0", n)
+	}
+}
+
+func TestRetainFunc(t *testing.T) {
+	tests := []struct {
+		pred    func(int) bool
+		want    []int
+		removed int
+	}{
+		{func(int) bool { return false }, []int{}, 5},
+		{func(int) bool { return true }, []int{1, 2, 3, 4, 5}, 0},
+		{func(v int) bool { return v > 1 && v < 5 }, []int{2, 3, 4}, 2},
+	}
+	for _, tt := range tests {
+		lst := FromSlice([]int{1, 2, 3, 4, 5})
+		if n := lst.RetainFunc(tt.pred); n != tt.removed {
+			t.Fatalf("removed %d, want %d", n, tt.removed)
+		}
+		checkList(t, lst, tt.want)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	lst := FromSlice([]int{1, 2, 3, 4})
+	if sum := Reduce(lst, 0, func(acc, v int) int { return acc + v }); sum != 10 {
+		t.Fatalf("got sum %d, want 10", sum)
+	}
+	join := func(acc string, v int) string {
+		if acc != "" {
+			acc += ","
+		}
+		return acc + strconv.Itoa(v)
+	}
+	if s := Reduce(lst, "", join); s != "1,2,3,4" {
+		t.Fatalf("got %q, want %q", s, "1,2,3,4")
+	}
+	if s := Reduce(New[int](), "init", join); s != "init" {
+		t.Fatalf("empty list: got %q", s)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	lst := FromSlice([]string{"a", "b", "c"})
+	var got []string
+	lst.ForEach(func(v string) { got = append(got, v) })
+	if !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestForEachElement(t *testing.T) {
+	lst := FromSlice([]int{1, 2, 3, 4, 5})
+	var found *Element[int]
+	visited := 0
+	lst.ForEachElement(func(e *Element[int]) bool {
+		visited++
+		if e.Value == 3 {
+			found = e
+			return false
+		}
+		return true
+	})
+	if found == nil || found.Value != 3 || visited != 3 {
+		t.Fatalf("found %v after %d visits", found, visited)
+	}
+
+	lst.ForEachElement(func(e *Element[int]) bool {
+		if e.Value%2 == 1 {
+			lst.Remove(e)
+		}
+		return true
+	})
+	checkList(t, lst, []int{2, 4})
+}
+
+func TestContainsIndexOf(t *testing.T) {
+	lst := FromSlice([]int{4, 7, 9, 7})
+	tests := []struct {
+		v     int
+		index int
+	}{
+		{4, 0},
+		{7, 1},
+		{9, 2},
+		{5, -1},
+	}
+	for _, tt := range tests {
+		if got := IndexOf(lst, tt.v); got != tt.index {
+			t.Errorf("IndexOf(%d) = %d, want %d", tt.v, got, tt.index)
+		}
+		if got := Contains(lst, tt.v); got != (tt.index >= 0) {
+			t.Errorf("Contains(%d) = %v", tt.v, got)
+		}
+	}
+	if Contains(New[int](), 0) || IndexOf(New[int](), 0) != -1 {
+		t.Error("empty list reported a match")
+	}
+}
+
+func TestContainsFuncIndexOfFunc(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	lst := FromSlice([]user{{"ann", 31}, {"bob", 17}, {"cat", 45}})
+	byName := func(name string) func(user) bool {
+		return func(u user) bool { return u.name == name }
+	}
+	if i := lst.IndexOfFunc(byName("cat")); i != 2 {
+		t.Fatalf("got %d, want 2", i)
+	}
+	if !lst.ContainsFunc(byName("bob")) {
+		t.Fatal("bob not found")
+	}
+	if i := lst.IndexOfFunc(byName("dan")); i != -1 {
+		t.Fatalf("got %d, want -1", i)
+	}
+	if lst.ContainsFunc(func(u user) bool { return u.age > 50 }) {
+		t.Fatal("unexpected match")
+	}
+}
+
+func TestAt(t *testing.T) {
+	lst := FromSlice([]int{0, 1, 2, 3, 4, 5, 6})
+	for _, i := range []int{0, 2, 3, 4, 6} {
+		if e := lst.At(i); e == nil || e.Value != i {
+			t.Fatalf("At(%d) = %v", i, e)
+		}
+	}
+	for _, i := range []int{-1, 7, 100} {
+		if e := lst.At(i); e != nil {
+			t.Fatalf("At(%d) = %v, want nil", i, e.Value)
+		}
+	}
+	var zero List[int]
+	if e := zero.At(0); e != nil {
+		t.Fatal("zero list: got an element")
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	lst := FromSlice([]int{0, 1, 2, 3, 4})
+	steps := []struct {
+		i    int
+		v    int
+		ok   bool
+		want []int
+	}{
+		{0, 0, true, []int{1, 2, 3, 4}},
+		{3, 4, true, []int{1, 2, 3}},
+		{1, 2, true, []int{1, 3}},
+		{2, 0, false, []int{1, 3}},
+		{-1, 0, false, []int{1, 3}},
+	}
+	for _, s := range steps {
+		if v, ok := lst.RemoveAt(s.i); v != s.v || ok != s.ok {
+			t.Fatalf("RemoveAt(%d) = %d, %v, want %d, %v", s.i, v, ok, s.v, s.ok)
+		}
+		checkList(t, lst, s.want)
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	var lst List[int]
+	if e := lst.InsertAt(0, 2); e == nil || e.Value != 2 {
+		t.Fatalf("InsertAt(0) on empty list = %v", e)
+	}
+	lst.InsertAt(0, 0)
+	lst.InsertAt(2, 4)
+	lst.InsertAt(1, 1)
+	lst.InsertAt(3, 3)
+	checkList(t, &lst, []int{0, 1, 2, 3, 4})
+	for _, i := range []int{-1, 6} {
+		if e := lst.InsertAt(i, 9); e != nil {
+			t.Fatalf("InsertAt(%d) = %v, want nil", i, e.Value)
+		}
+	}
+	checkList(t, &lst, []int{0, 1, 2, 3, 4})
+}
+
+func TestPopFrontPopBack(t *testing.T) {
+	lst := FromSlice([]int{1, 2, 3, 4})
+	var got []int
+	for {
+		v, ok := lst.PopFront()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+		if v, ok = lst.PopBack(); ok {
+			got = append(got, v)
+		}
+	}
+	if !slices.Equal(got, []int{1, 4, 2, 3}) {
+		t.Fatalf("got %v, want [1 4 2 3]", got)
+	}
+	checkList(t, lst, []int{})
+	var zero List[int]
+	if v, ok := zero.PopFront(); ok || v != 0 {
+		t.Fatalf("PopFront on empty list = %d, %v", v, ok)
+	}
+	if v, ok := zero.PopBack(); ok || v != 0 {
+		t.Fatalf("PopBack on empty list = %d, %v", v, ok)
+	}
+}
+
+func TestPeekFrontPeekBack(t *testing.T) {
+	lst := FromSlice([]string{"a", "b", "c"})
+	if v, ok := lst.PeekFront(); !ok || v != "a" {
+		t.Fatalf("PeekFront = %q, %v", v, ok)
+	}
+	if v, ok := lst.PeekBack(); !ok || v != "c" {
+		t.Fatalf("PeekBack = %q, %v", v, ok)
+	}
+	checkList(t, lst, []string{"a", "b", "c"})
+	var zero List[string]
+	if v, ok := zero.PeekFront(); ok || v != "" {
+		t.Fatalf("PeekFront on empty list = %q, %v", v, ok)
+	}
+	if v, ok := zero.PeekBack(); ok || v != "" {
+		t.Fatalf("PeekBack on empty list = %q, %v", v, ok)
+	}
+}
+
+func TestClearIsEmpty(t *testing.T) {
+	var lst List[int]
+	if !lst.IsEmpty() {
+		t.Fatal("zero list not empty")
+	}
+	e := lst.PushBack(1)
+	lst.PushBack(2)
+	if lst.IsEmpty() {
+		t.Fatal("populated list reported empty")
+	}
+	lst.Clear()
+	if !lst.IsEmpty() {
+		t.Fatal("cleared list not empty")
+	}
+	checkList(t, &lst, []int{})
+	if e.list != nil || e.next != nil || e.prev != nil || e.Value != 1 {
+		t.Fatal("old element still attached")
+	}
+	lst.PushBack(3)
+	checkList(t, &lst, []int{3})
+}
+
+func TestClone(t *testing.T) {
+	src := FromSlice([]int{1, 2, 3})
+	c := src.Clone()
+	checkList(t, c, []int{1, 2, 3})
+	c.Remove(c.Front())
+	c.Back().Value = 9
+	c.PushBack(4)
+	checkList(t, c, []int{2, 9, 4})
+	checkList(t, src, []int{1, 2, 3})
+	var zero List[int]
+	checkList(t, zero.Clone(), []int{})
+}
+
+func TestCloneFunc(t *testing.T) {
+	src := New[[]int]()
+	src.PushBack([]int{1, 2})
+	src.PushBack([]int{3})
+	c := src.CloneFunc(slices.Clone[[]int])
+	c.Front().Value[0] = 9
+	if got := src.Front().Value[0]; got != 1 {
+		t.Fatalf("source changed to %d", got)
+	}
+	if !slices.Equal(c.Back().Value, []int{3}) || c.Len() != 2 {
+		t.Fatalf("clone holds %v", c.ToSlice())
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b []int
+		want bool
+	}{
+		{[]int{1, 2, 3}, []int{1, 2, 3}, true},
+		{nil, nil, true},
+		{[]int{1, 2}, []int{1, 2, 3}, false},
+		{[]int{1, 2, 3}, []int{1, 5, 3}, false},
+	}
+	for _, tt := range tests {
+		a, b := FromSlice(tt.a), FromSlice(tt.b)
+		if got := Equal(a, b); got != tt.want {
+			t.Errorf("Equal(%v, %v) = %v", tt.a, tt.b, got)
+		}
+		if got := Equal(b, a); got != tt.want {
+			t.Errorf("Equal(%v, %v) = %v", tt.b, tt.a, got)
+		}
+	}
+	fold := func(x, y string) bool { return strings.EqualFold(x, y) }
+	if !EqualFunc(FromSlice([]string{"a", "B"}), FromSlice([]string{"A", "b"}), fold) {
+		t.Error("EqualFunc: case-folded lists not equal")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	lst := New[int]()
+	var es []*Element[int]
+	for i := 0; i < 5; i++ {
+		es = append(es, lst.PushBack(i))
+	}
+	lst.Reverse()
+	if got := lst.ToSlice(); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Fatalf("got %v, want [4 3 2 1 0]", got)
+	}
+	for i, e := range es {
+		if lst.At(4-i) != e || e.Value != i {
+			t.Fatalf("element %d not at index %d after reverse", i, 4-i)
+		}
+	}
+	lst.Reverse()
+	checkList(t, lst, []int{0, 1, 2, 3, 4})
+}
+
+func TestQuickSortSortedInput(t *testing.T) {
+	const n = 1000000
+	for _, desc := range []bool{false, true} {
+		lst := New[int]()
+		for i := 0; i < n; i++ {
+			if desc {
+				lst.PushFront(i)
+			} else {
+				lst.PushBack(i)
+			}
+		}
+		lst.QuickSort(cmp.Compare[int])
+		if lst.Len() != n || !slices.IsSorted(lst.ToSlice()) {
+			t.Fatalf("descending=%v: list not sorted", desc)
+		}
+	}
+}
+
+func TestQuickSortPathological(t *testing.T) {
+	const n = 1000000
+	patterns := map[string]func(i int) int{
+		"organ pipe": func(i int) int { return min(i, n-1-i) },
+		"sawtooth":   func(i int) int { return i % 1000 },
+		"constant":   func(i int) int { return 7 },
+		"interleave": func(i int) int {
+			if i%2 == 0 {
+				return i
+			}
+			return n - i
+		},
+	}
+	for name, f := range patterns {
+		lst := New[int]()
+		for i := 0; i < n; i++ {
+			lst.PushBack(f(i))
+		}
+		lst.QuickSort(cmp.Compare[int])
+		if lst.Len() != n || !slices.IsSorted(lst.ToSlice()) {
+			t.Fatalf("%s: list not sorted", name)
+		}
+		if err := lst.WellFormed(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestQuickSortSmall(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for n := 0; n <= 32; n++ {
+		for k := 0; k < 20; k++ {
+			lst := New[int]()
+			want := make([]int, n)
+			for i := range want {
+				want[i] = r.Intn(n + 1)
+				lst.PushBack(want[i])
+			}
+			slices.Sort(want)
+			lst.QuickSort(cmp.Compare[int])
+			checkList(t, lst, want)
+		}
+	}
+}
+
+func BenchmarkQuickSortCutoff(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 50000)
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+	for _, cutoff := range []int{0, qsortCutoff} {
+		b.Run(fmt.Sprintf("cutoff=%d", cutoff), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				lst := FromSlice(vals)
+				b.StartTimer()
+				_qsort(lst, lst.Front(), lst.Back(), lst.len, 2*bits.Len(uint(lst.len)), cutoff, cmp.Compare[int])
+			}
+		})
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	type person struct {
+		last, first string
+	}
+	lst := FromSlice([]person{
+		{"smith", "zoe"}, {"jones", "amy"}, {"smith", "bob"},
+		{"brown", "kim"}, {"jones", "eve"}, {"smith", "al"},
+	})
+	lst.SortStable(func(a, b person) int { return strings.Compare(a.first, b.first) })
+	lst.SortStable(func(a, b person) int { return strings.Compare(a.last, b.last) })
+	checkList(t, lst, []person{
+		{"brown", "kim"}, {"jones", "amy"}, {"jones", "eve"},
+		{"smith", "al"}, {"smith", "bob"}, {"smith", "zoe"},
+	})
+	var zero List[int]
+	zero.SortStable(cmp.Compare[int])
+	checkList(t, &zero, []int{})
+}
+
+func TestIsSorted(t *testing.T) {
+	tests := []struct {
+		vals []int
+		want bool
+	}{
+		{nil, true},
+		{[]int{5}, true},
+		{[]int{1, 2, 2, 3}, true},
+		{[]int{1, 3, 2}, false},
+		{[]int{2, 1}, false},
+	}
+	for _, tt := range tests {
+		if got := FromSlice(tt.vals).IsSorted(cmp.Compare[int]); got != tt.want {
+			t.Errorf("IsSorted(%v) = %v, want %v", tt.vals, got, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := FromSlice([]int{2, 4, 6, 8})
+	b := FromSlice([]int{1, 3, 4, 9})
+	a.Merge(b, cmp.Compare[int])
+	checkList(t, a, []int{1, 2, 3, 4, 4, 6, 8, 9})
+	checkList(t, b, []int{})
+}
+
+func TestShuffle(t *testing.T) {
+	vals := make([]int, 50)
+	for i := range vals {
+		vals[i] = i % 10
+	}
+	a, b := FromSlice(vals), FromSlice(vals)
+	a.Shuffle(rand.New(rand.NewSource(42)))
+	b.Shuffle(rand.New(rand.NewSource(42)))
+	checkList(t, b, a.ToSlice())
+	if Equal(a, FromSlice(vals)) {
+		t.Fatal("shuffle left the order unchanged")
+	}
+	got := a.ToSlice()
+	slices.Sort(got)
+	want := slices.Sorted(slices.Values(vals))
+	if !slices.Equal(got, want) {
+		t.Fatalf("shuffle changed the values: %v", got)
+	}
+	a.Shuffle(nil)
+	if a.Len() != len(vals) {
+		t.Fatalf("len %d, want %d", a.Len(), len(vals))
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	lst := New[int]()
+	if e, ok := lst.Min(cmp.Compare[int]); e != nil || ok {
+		t.Fatal("Min on empty list reported an element")
+	}
+	if e, ok := lst.Max(cmp.Compare[int]); e != nil || ok {
+		t.Fatal("Max on empty list reported an element")
+	}
+	es := make([]*Element[int], 0)
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 9} {
+		es = append(es, lst.PushBack(v))
+	}
+	if e, ok := lst.Min(cmp.Compare[int]); !ok || e != es[1] {
+		t.Fatalf("Min = %v, want the first 1", e.Value)
+	}
+	if e, ok := lst.Max(cmp.Compare[int]); !ok || e != es[5] {
+		t.Fatalf("Max = %v, want the first 9", e.Value)
+	}
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	lst := FromSlice([]string{"go", "list", "generic", "sort", "a", "ringbuf"})
+	length := func(s string) int { return len(s) }
+	if e, ok := MaxBy(lst, length); !ok || e.Value != "generic" {
+		t.Fatalf("MaxBy = %v, %v, want generic", e.Value, ok)
+	}
+	if e, ok := MinBy(lst, length); !ok || e.Value != "a" {
+		t.Fatalf("MinBy = %v, %v, want a", e.Value, ok)
+	}
+	if e, ok := MinBy(New[string](), length); e != nil || ok {
+		t.Fatal("MinBy on empty list reported an element")
+	}
+}
+
+func TestSwap(t *testing.T) {
+	lst := New[int]()
+	var es []*Element[int]
+	for i := 0; i < 5; i++ {
+		es = append(es, lst.PushBack(i))
+	}
+	lst.Swap(es[1], es[2])
+	checkList(t, lst, []int{0, 2, 1, 3, 4})
+	lst.Swap(es[1], es[2])
+	checkList(t, lst, []int{0, 1, 2, 3, 4})
+	lst.Swap(es[3], es[1])
+	checkList(t, lst, []int{0, 3, 2, 1, 4})
+	lst.Swap(es[0], es[4])
+	checkList(t, lst, []int{4, 3, 2, 1, 0})
+	if lst.Front() != es[4] || lst.Back() != es[0] {
+		t.Fatal("front and back elements not exchanged")
+	}
+	lst.Swap(es[2], es[2])
+	checkList(t, lst, []int{4, 3, 2, 1, 0})
+
+	other := New[int]()
+	foreign := other.PushBack(9)
+	lst.Swap(es[0], foreign)
+	checkList(t, lst, []int{4, 3, 2, 1, 0})
+	checkList(t, other, []int{9})
+
+	two := New[int]()
+	a, b := two.PushBack(1), two.PushBack(2)
+	two.Swap(b, a)
+	checkList(t, two, []int{2, 1})
+}
+
+func TestMoveToIndex(t *testing.T) {
+	lst := New[int]()
+	var es []*Element[int]
+	for i := 0; i < 5; i++ {
+		es = append(es, lst.PushBack(i))
+	}
+	steps := []struct {
+		e, i int
+		want []int
+	}{
+		{1, 3, []int{0, 2, 3, 1, 4}},
+		{4, 1, []int{0, 4, 2, 3, 1}},
+		{0, 4, []int{4, 2, 3, 1, 0}},
+		{0, 0, []int{0, 4, 2, 3, 1}},
+		{2, 2, []int{0, 4, 2, 3, 1}},
+		{3, 99, []int{0, 4, 2, 1, 3}},
+		{3, -5, []int{3, 0, 4, 2, 1}},
+	}
+	for _, s := range steps {
+		lst.MoveToIndex(es[s.e], s.i)
+		checkList(t, lst, s.want)
+	}
+	other := New[int]()
+	lst.MoveToIndex(other.PushBack(7), 0)
+	checkList(t, lst, []int{3, 0, 4, 2, 1})
+}
+
+func TestSplitAt(t *testing.T) {
+	vals := []int{0, 1, 2, 3, 4}
+	for i := -1; i <= 6; i++ {
+		lst := FromSlice(vals)
+		front := lst.Front()
+		rest := lst.SplitAt(i)
+		cut := max(0, min(i, len(vals)))
+		checkList(t, lst, vals[:cut])
+		checkList(t, rest, vals[cut:])
+		if cut == 0 && rest.Front() != front {
+			t.Fatalf("i=%d: element handle not moved", i)
+		}
+	}
+	var zero List[int]
+	checkList(t, zero.SplitAt(0), []int{})
+}
+
+func TestPartition(t *testing.T) {
+	lst := FromSlice([]int{5, 2, 8, 1, 6, 3})
+	e := lst.Front()
+	big, small := lst.Partition(func(v int) bool { return v > 4 })
+	checkList(t, big, []int{5, 8, 6})
+	checkList(t, small, []int{2, 1, 3})
+	checkList(t, lst, []int{})
+	if big.Front() != e {
+		t.Fatal("element handle not moved")
+	}
+	all, none := FromSlice([]int{1, 2}).Partition(func(int) bool { return true })
+	checkList(t, all, []int{1, 2})
+	checkList(t, none, []int{})
+}
+
+func TestConcat(t *testing.T) {
+	a := FromSlice([]int{1, 2})
+	b := FromSlice([]int{3, 4, 5})
+	e := b.Front()
+	a.Concat(b)
+	checkList(t, a, []int{1, 2, 3, 4, 5})
+	checkList(t, b, []int{})
+	if e.list != a {
+		t.Fatal("moved element does not belong to the receiver")
+	}
+	a.Concat(a)
+	checkList(t, a, []int{1, 2, 3, 4, 5})
+	var zero List[int]
+	zero.Concat(a)
+	checkList(t, &zero, []int{1, 2, 3, 4, 5})
+	checkList(t, a, []int{})
+	b.PushBack(6)
+	checkList(t, b, []int{6})
+}
+
+func TestPushAll(t *testing.T) {
+	var lst List[int]
+	lst.PushBackAll(4, 5)
+	lst.PushFrontAll(1, 2, 3)
+	lst.PushBackAll(6)
+	lst.PushFrontAll()
+	checkList(t, &lst, []int{1, 2, 3, 4, 5, 6})
+}
+
+func TestElementSet(t *testing.T) {
+	lst := FromSlice([]string{"a", "b"})
+	lst.Front().Set("z")
+	if v := lst.Front().Value; v != "z" {
+		t.Fatalf("got %q, want %q", v, "z")
+	}
+	checkList(t, lst, []string{"z", "b"})
+}
+
+func TestElementList(t *testing.T) {
+	lst := New[int]()
+	e := lst.PushBack(1)
+	if e.List() != lst {
+		t.Fatal("element does not report its list")
+	}
+	lst.Remove(e)
+	if e.List() != nil {
+		t.Fatal("removed element still reports a list")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	ints := FromSlice([]int{1, 2, 3})
+	b, err := json.Marshal(ints)
+	if err != nil || string(b) != "[1,2,3]" {
+		t.Fatalf("got %s, %v", b, err)
+	}
+	var back List[int]
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	checkList(t, &back, []int{1, 2, 3})
+
+	if b, err := json.Marshal(New[int]()); err != nil || string(b) != "[]" {
+		t.Fatalf("empty list: got %s, %v", b, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &back); err != nil {
+		t.Fatal(err)
+	}
+	checkList(t, &back, []int{})
+
+	type point struct {
+		X, Y int
+	}
+	points := FromSlice([]point{{1, 2}, {3, 4}})
+	b, err = json.Marshal(struct{ Points *List[point] }{points})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wrapper struct{ Points *List[point] }
+	if err := json.Unmarshal(b, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	checkList(t, wrapper.Points, []point{{1, 2}, {3, 4}})
+
+	keep := FromSlice([]int{7})
+	if err := json.Unmarshal([]byte(`["x"]`), keep); err == nil {
+		t.Fatal("decoding a string into List[int] succeeded")
+	}
+	checkList(t, keep, []int{7})
+}
+
+func TestGob(t *testing.T) {
+	for _, vals := range [][]string{{"a", "b", "c"}, {}} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(FromSlice(vals)); err != nil {
+			t.Fatal(err)
+		}
+		back := FromSlice([]string{"stale"})
+		if err := gob.NewDecoder(&buf).Decode(back); err != nil {
+			t.Fatal(err)
+		}
+		checkList(t, back, vals)
+	}
+}
+
+func TestQuickSortFunc(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	vals := make([]int, 500)
+	for i := range vals {
+		vals[i] = r.Intn(100)
+	}
+	lst := FromSlice(vals)
+	lst.QuickSortFunc(func(a, b int) bool { return a > b })
+	slices.Sort(vals)
+	slices.Reverse(vals)
+	checkList(t, lst, vals)
+}
+
+func TestSort(t *testing.T) {
+	ints := []int{5, -3, 9, 0, 5, 2, -3, 7}
+	li := FromSlice(ints)
+	Sort(li)
+	slices.Sort(ints)
+	checkList(t, li, ints)
+
+	strs := []string{"pear", "apple", "fig", "banana", "apple"}
+	ls := FromSlice(strs)
+	Sort(ls)
+	checkList(t, ls, []string{"apple", "apple", "banana", "fig", "pear"})
+
+	empty := New[int]()
+	Sort(empty)
+	checkList(t, empty, []int{})
+}
+
+func TestSyncListConcurrent(t *testing.T) {
+	s := NewSyncList[int]()
+	const workers, perWorker = 8, 1000
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if i%2 == 0 {
+					s.PushBack(w*perWorker + i)
+				} else {
+					s.PushFront(w*perWorker + i)
+				}
+				if i%10 == 0 {
+					s.Range(func(int) bool { return true })
+					_ = s.Len()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if n := s.Len(); n != workers*perWorker {
+		t.Fatalf("Len() = %d after pushes, want %d", n, workers*perWorker)
+	}
+
+	seen := make(map[int]bool)
+	var mu sync.Mutex
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				var v int
+				var ok bool
+				if w%2 == 0 {
+					v, ok = s.PopFront()
+				} else {
+					v, ok = s.PopBack()
+				}
+				if !ok {
+					return
+				}
+				mu.Lock()
+				seen[v] = true
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	if len(seen) != workers*perWorker || s.Len() != 0 {
+		t.Fatalf("popped %d distinct values leaving %d, want %d leaving 0", len(seen), s.Len(), workers*perWorker)
+	}
+
+	e := s.PushBack(1)
+	s.PushBack(2)
+	if v := s.Remove(e); v != 1 {
+		t.Fatalf("Remove() = %d, want 1", v)
+	}
+	s.RLock()
+	checkList(t, s.List, []int{2})
+	s.RUnlock()
+}
+
+func TestSyncListZeroValue(t *testing.T) {
+	var s SyncList[int]
+	if n := s.Len(); n != 0 {
+		t.Fatalf("Len() of zero SyncList = %d, want 0", n)
+	}
+	s.Range(func(int) bool {
+		t.Fatal("Range on zero SyncList called f")
+		return false
+	})
+	if _, ok := s.PopBack(); ok {
+		t.Fatal("PopBack() on zero SyncList reported a value")
+	}
+
+	var front SyncList[int]
+	front.PushFront(2)
+	front.PushFront(1)
+	checkList(t, front.List, []int{1, 2})
+
+	s.PushBack(1)
+	s.PushBack(2)
+	if v, ok := s.PopFront(); !ok || v != 1 {
+		t.Fatalf("PopFront() = %d, %v, want 1, true", v, ok)
+	}
+	checkList(t, s.List, []int{2})
+}
+
+func TestStringJoin(t *testing.T) {
+	l := New[int]()
+	if s := l.String(); s != "" {
+		t.Fatalf("String() of empty list = %q, want %q", s, "")
+	}
+	l.PushBackAll(1, 2, 3)
+	if s := l.String(); s != "1 2 3" {
+		t.Fatalf("String() = %q, want %q", s, "1 2 3")
+	}
+	hex := func(v int) string { return fmt.Sprintf("%#x", v) }
+	if s := l.Join(", ", hex); s != "0x1, 0x2, 0x3" {
+		t.Fatalf("Join() = %q, want %q", s, "0x1, 0x2, 0x3")
+	}
+	if s := New[int]().Join(", ", hex); s != "" {
+		t.Fatalf("Join() of empty list = %q, want %q", s, "")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	l := FromSlice([]int{1, 1, 2, 3, 3, 3, 1})
+	if n := Compact(l); n != 3 {
+		t.Fatalf("Compact() = %d, want 3", n)
+	}
+	checkList(t, l, []int{1, 2, 3, 1})
+
+	if n := Compact(New[int]()); n != 0 {
+		t.Fatalf("Compact() on empty list = %d, want 0", n)
+	}
+
+	s := FromSlice([]string{"Go", "GO", "go", "list", "LIST", "go"})
+	if n := s.CompactFunc(strings.EqualFold); n != 3 {
+		t.Fatalf("CompactFunc() = %d, want 3", n)
+	}
+	checkList(t, s, []string{"Go", "list", "go"})
+}
+
+func TestUnique(t *testing.T) {
+	l := FromSlice([]int{3, 1, 3, 2, 1})
+	if n := Unique(l); n != 2 {
+		t.Fatalf("Unique() = %d, want 2", n)
+	}
+	checkList(t, l, []int{3, 1, 2})
+
+	s := FromSlice([]string{"pear", "Plum", "apple", "peach", "Avocado"})
+	if n := UniqueBy(s, func(v string) byte { return v[0] | 0x20 }); n != 3 {
+		t.Fatalf("UniqueBy() = %d, want 3", n)
+	}
+	checkList(t, s, []string{"pear", "apple"})
+}
+
+func TestCount(t *testing.T) {
+	l := FromSlice([]int{4, 2, 4, 4, 7, 2})
+	for v, want := range map[int]int{4: 3, 2: 2, 7: 1, 9: 0} {
+		if n := Count(l, v); n != want {
+			t.Fatalf("Count(%d) = %d, want %d", v, n, want)
+		}
+	}
+
+	type item struct {
+		name  string
+		stock int
+	}
+	items := FromSlice([]item{{"bolt", 0}, {"nut", 12}, {"washer", 0}, {"screw", 3}})
+	if n := items.CountFunc(func(it item) bool { return it.stock == 0 }); n != 2 {
+		t.Fatalf("CountFunc() = %d, want 2", n)
+	}
+	if n := New[item]().CountFunc(func(item) bool { return true }); n != 0 {
+		t.Fatalf("CountFunc() on empty list = %d, want 0", n)
+	}
+}
+
+func TestAnyEvery(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		in         []int
+		any, every bool
+	}{
+		{[]int{}, false, true},
+		{[]int{2, 4, 6}, true, true},
+		{[]int{1, 3, 5}, false, false},
+		{[]int{1, 4, 5}, true, false},
+	}
+	for _, tt := range tests {
+		l := FromSlice(tt.in)
+		if got := l.Any(even); got != tt.any {
+			t.Fatalf("Any() on %v = %v, want %v", tt.in, got, tt.any)
+		}
+		if got := l.Every(even); got != tt.every {
+			t.Fatalf("Every() on %v = %v, want %v", tt.in, got, tt.every)
+		}
+	}
+
+	calls := 0
+	FromSlice([]int{1, 2, 3, 4}).Every(func(v int) bool { calls++; return v < 2 })
+	if calls != 2 {
+		t.Fatalf("Every() called pred %d times, want 2", calls)
+	}
+}
+
+func TestFindFunc(t *testing.T) {
+	l := FromSlice([]int{2, 5, 8, 5, 3})
+	front, back := l.Front(), l.Back()
+	mid := front.Next()
+
+	eq := func(x int) func(int) bool { return func(v int) bool { return v == x } }
+	if e := l.FindFunc(eq(2)); e != front {
+		t.Fatalf("FindFunc(2) = %v, want front", e)
+	}
+	if e := l.FindLastFunc(eq(3)); e != back {
+		t.Fatalf("FindLastFunc(3) = %v, want back", e)
+	}
+	if e := l.FindFunc(eq(5)); e != mid {
+		t.Fatalf("FindFunc(5) = %v, want second element", e)
+	}
+	if e := l.FindLastFunc(eq(5)); e != back.Prev() {
+		t.Fatalf("FindLastFunc(5) = %v, want fourth element", e)
+	}
+	if e := l.FindFunc(eq(9)); e != nil {
+		t.Fatalf("FindFunc(9) = %v, want nil", e)
+	}
+	if e := l.FindLastFunc(eq(9)); e != nil {
+		t.Fatalf("FindLastFunc(9) = %v, want nil", e)
+	}
+
+	l.MoveToFront(l.FindLastFunc(eq(5)))
+	l.Remove(l.FindFunc(eq(8)))
+	checkList(t, l, []int{5, 2, 5, 3})
+}
+
+func TestReplace(t *testing.T) {
+	l := FromSlice([]string{"a", "b", "a", "c", "a"})
+	if Replace(l, "z", "y") {
+		t.Fatalf("Replace(z) = true, want false")
+	}
+	if n := ReplaceAll(l, "z", "y"); n != 0 {
+		t.Fatalf("ReplaceAll(z) = %d, want 0", n)
+	}
+	checkList(t, l, []string{"a", "b", "a", "c", "a"})
+
+	front := l.Front()
+	if !Replace(l, "a", "x") {
+		t.Fatalf("Replace(a) = false, want true")
+	}
+	if l.Front() != front {
+		t.Fatalf("Replace relinked the front element")
+	}
+	checkList(t, l, []string{"x", "b", "a", "c", "a"})
+
+	if n := ReplaceAll(l, "a", "x"); n != 2 {
+		t.Fatalf("ReplaceAll(a) = %d, want 2", n)
+	}
+	checkList(t, l, []string{"x", "b", "x", "c", "x"})
+}
+
+func TestFill(t *testing.T) {
+	l := New[int]()
+	l.Resize(5, 0)
+	l.Apply(func(int) int { return rand.Int() })
+	front := l.Front()
+	l.Fill(7)
+	if l.Front() != front {
+		t.Fatalf("Fill relinked the front element")
+	}
+	checkList(t, l, []int{7, 7, 7, 7, 7})
+}
+
+func TestTakeDrop(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	l := FromSlice(in)
+	for _, n := range []int{-1, 0, 2, 5, 9} {
+		k := min(max(n, 0), len(in))
+		checkList(t, l.Take(n), in[:k])
+		checkList(t, l.Drop(n), in[k:])
+	}
+	checkList(t, l, in)
+
+	taken := l.Take(1)
+	taken.Front().Value = 100
+	if l.Front().Value != 1 {
+		t.Fatalf("modifying Take result changed source front to %d", l.Front().Value)
+	}
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	in := []int{2, 4, 7, 8, 9}
+	l := FromSlice(in)
+	tests := []struct {
+		pred func(int) bool
+		k    int
+	}{
+		{func(v int) bool { return v > 100 }, 0},       // matches nothing
+		{func(v int) bool { return v%2 == 0 }, 2},      // matches a prefix
+		{func(v int) bool { return v < 100 }, len(in)}, // matches everything
+	}
+	for _, tt := range tests {
+		checkList(t, l.TakeWhile(tt.pred), in[:tt.k])
+		checkList(t, l.DropWhile(tt.pred), in[tt.k:])
+	}
+	checkList(t, l, in)
+}
+
+func TestChunk(t *testing.T) {
+	even := FromSlice([]int{1, 2, 3, 4, 5, 6})
+	chunks := Chunk(even, 3)
+	if len(chunks) != 2 {
+		t.Fatalf("Chunk(6 values, 3) returned %d chunks, want 2", len(chunks))
+	}
+	checkList(t, chunks[0], []int{1, 2, 3})
+	checkList(t, chunks[1], []int{4, 5, 6})
+
+	uneven := FromSlice([]int{1, 2, 3, 4, 5})
+	chunks = Chunk(uneven, 2)
+	if len(chunks) != 3 {
+		t.Fatalf("Chunk(5 values, 2) returned %d chunks, want 3", len(chunks))
+	}
+	checkList(t, chunks[0], []int{1, 2})
+	checkList(t, chunks[1], []int{3, 4})
+	checkList(t, chunks[2], []int{5})
+	checkList(t, uneven, []int{1, 2, 3, 4, 5})
+
+	if chunks = Chunk(New[int](), 4); len(chunks) != 0 {
+		t.Fatalf("Chunk(empty, 4) returned %d chunks, want 0", len(chunks))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Chunk(0) did not panic")
+		}
+	}()
+	Chunk(even, 0)
+}